@@ -0,0 +1,485 @@
+package gocn2an
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// AlignmentSpan 输出片段与输入片段的对应关系，偏移均为字节偏移；
+// Changed 为 false 时两段文本相同，为 true 时输出片段由输入片段改写而来（输入或输出片段可能为空）
+type AlignmentSpan struct {
+	SrcStart int
+	SrcEnd   int
+	DstStart int
+	DstEnd   int
+	Changed  bool
+}
+
+// Alignment 输出与输入的对齐，按位置排列，依次覆盖整个输入和整个输出
+type Alignment []AlignmentSpan
+
+// TransformWithAlignment 同 Transform，并返回输出与输入的对齐，
+// 可用于将转换后文本中的位置映射回原文（如字幕时间戳、高亮）
+func (t *Transform) TransformWithAlignment(inputs, method string) (string, Alignment, error) {
+	output, alignment, failures, err := t.transformWithFailures(inputs, method)
+	if err != nil {
+		return "", nil, err
+	}
+	return output, alignment, t.reportFailures(failures)
+}
+
+// transformWithFailures 执行句子转换，返回对齐及未能转换的片段
+func (t *Transform) transformWithFailures(inputs, method string) (string, Alignment, []ConversionError, error) {
+	rec := newAlignmentRecorder(inputs)
+	output, err := t.transform(inputs, method, rec)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	// 保护词语等经占位符还原后与原文相同的片段不算改写
+	var alignment Alignment
+	for _, span := range rec.alignment {
+		if span.Changed && inputs[span.SrcStart:span.SrcEnd] == output[span.DstStart:span.DstEnd] {
+			span.Changed = false
+		}
+		alignment = appendSpan(alignment, span)
+	}
+	return output, alignment, rec.failures, nil
+}
+
+// Source 返回输出 [start, end) 对应的输入范围，部分覆盖改写片段时取整个改写片段
+func (a Alignment) Source(start, end int) (int, int) {
+	return a.project(start, end, func(s AlignmentSpan) (int, int, int, int) {
+		return s.DstStart, s.DstEnd, s.SrcStart, s.SrcEnd
+	})
+}
+
+// Target 返回输入 [start, end) 对应的输出范围，部分覆盖改写片段时取整个改写片段
+func (a Alignment) Target(start, end int) (int, int) {
+	return a.project(start, end, func(s AlignmentSpan) (int, int, int, int) {
+		return s.SrcStart, s.SrcEnd, s.DstStart, s.DstEnd
+	})
+}
+
+// project 将一侧的范围映射到另一侧，sides 返回 span 在来源侧和目标侧的范围
+func (a Alignment) project(start, end int, sides func(AlignmentSpan) (int, int, int, int)) (int, int) {
+	lo, hi := -1, -1
+	// 结束于 start 之前的片段与范围不相交
+	first := sort.Search(len(a), func(i int) bool {
+		_, to, _, _ := sides(a[i])
+		return to > start
+	})
+	for _, span := range a[first:] {
+		from, to, mappedFrom, mappedTo := sides(span)
+		if from > end || from == end && start < end {
+			// 之后的片段都在范围之后
+			break
+		}
+		overlaps := start < to && from < end || start == end && from <= start && start < to
+		if !overlaps {
+			continue
+		}
+		if span.Changed {
+			if lo == -1 {
+				lo = mappedFrom
+			}
+			hi = mappedTo
+			continue
+		}
+		if lo == -1 {
+			lo = mappedFrom + max(start, from) - from
+		}
+		hi = mappedFrom + min(end, to) - from
+	}
+	if lo == -1 {
+		// 超出范围或落在末尾
+		if len(a) == 0 {
+			return 0, 0
+		}
+		_, _, _, last := sides(a[len(a)-1])
+		return last, last
+	}
+	if start == end {
+		return lo, lo
+	}
+	return lo, hi
+}
+
+// alignmentRecorder 记录句子转换每一步的中间结果并累积对齐，以及未能转换的片段；为 nil 时不记录
+type alignmentRecorder struct {
+	alignment Alignment
+	source    string
+	current   string
+	failures  []ConversionError
+}
+
+// newAlignmentRecorder 以原文创建记录器
+func newAlignmentRecorder(inputs string) *alignmentRecorder {
+	rec := &alignmentRecorder{source: inputs, current: inputs}
+	if inputs != "" {
+		rec.alignment = Alignment{{SrcEnd: len(inputs), DstEnd: len(inputs)}}
+	}
+	return rec
+}
+
+// textEdit 一步转换中的一处改写：中间结果的 [start, end) 替换为 text
+type textEdit struct {
+	start int
+	end   int
+	text  string
+	// whole 为 true 时整处改写为一个改写片段，不再按字符细分，如 |y| => y的绝对值
+	whole bool
+}
+
+// record 记录一步由 edits 完成的转换，s 为转换结果；edits 为当前中间结果中按位置排列且互不重叠的改写
+func (r *alignmentRecorder) record(s string, edits []textEdit) {
+	if r == nil || s == r.current {
+		return
+	}
+	r.alignment = composeAlignment(r.alignment, editAlignment(r.current, edits))
+	r.current = s
+}
+
+// fail 记录当前中间结果中 [start, end) 未能转换，位置换回原文；已被先前阶段记录的片段不重复记录
+func (r *alignmentRecorder) fail(start, end int, category string, err error) {
+	if r == nil {
+		return
+	}
+	srcStart, srcEnd := r.alignment.Source(start, end)
+	for _, failure := range r.failures {
+		if failure.Start <= srcStart && srcEnd <= failure.End {
+			return
+		}
+	}
+	r.failures = append(r.failures, ConversionError{
+		Start:    srcStart,
+		End:      srcEnd,
+		Text:     r.source[srcStart:srcEnd],
+		Category: category,
+		Err:      err,
+	})
+}
+
+// editBuilder 逐字符拼接转换结果并记录改写位置
+type editBuilder struct {
+	builder strings.Builder
+	edits   []textEdit
+	// pos 输入中已处理的字节数
+	pos int
+}
+
+// keep 原样写入输入中的下一个字符
+func (b *editBuilder) keep(r rune) {
+	b.builder.WriteRune(r)
+	b.pos += utf8.RuneLen(r)
+}
+
+// keepString 原样写入输入中接下来的 text
+func (b *editBuilder) keepString(text string) {
+	b.builder.WriteString(text)
+	b.pos += len(text)
+}
+
+// replace 将输入中从下一个字符起的 old 改写为 text
+func (b *editBuilder) replace(old, text string) {
+	b.builder.WriteString(text)
+	b.edits = append(b.edits, textEdit{start: b.pos, end: b.pos + len(old), text: text})
+	b.pos += len(old)
+}
+
+// replaceWhole 同 replace，改写不再按字符细分
+func (b *editBuilder) replaceWhole(old, text string) {
+	b.replace(old, text)
+	b.edits[len(b.edits)-1].whole = true
+}
+
+// finish 返回转换结果并记录到 rec
+func (b *editBuilder) finish(rec *alignmentRecorder) string {
+	s := b.builder.String()
+	rec.record(s, b.edits)
+	return s
+}
+
+// editAlignment 由改写位置得到 s 到改写结果的对齐；每处改写内部再按字符做最短编辑，
+// 如 十一月 => 11月 中的「月」仍为相同片段，最短编辑不会跨越两处改写
+func editAlignment(s string, edits []textEdit) Alignment {
+	var alignment Alignment
+	src, dst := 0, 0
+	for _, e := range edits {
+		alignment = appendSpan(alignment, AlignmentSpan{SrcStart: src, SrcEnd: e.start, DstStart: dst, DstEnd: dst + e.start - src})
+		dst += e.start - src
+		if e.whole {
+			alignment = appendSpan(alignment, AlignmentSpan{SrcStart: e.start, SrcEnd: e.end, DstStart: dst, DstEnd: dst + len(e.text), Changed: true})
+			src, dst = e.end, dst+len(e.text)
+			continue
+		}
+		for _, span := range diffAlignment(s[e.start:e.end], e.text) {
+			span.SrcStart, span.SrcEnd = span.SrcStart+e.start, span.SrcEnd+e.start
+			span.DstStart, span.DstEnd = span.DstStart+dst, span.DstEnd+dst
+			alignment = appendSpan(alignment, span)
+		}
+		src, dst = e.end, dst+len(e.text)
+	}
+	return appendSpan(alignment, AlignmentSpan{SrcStart: src, SrcEnd: len(s), DstStart: dst, DstEnd: dst + len(s) - src})
+}
+
+// offsetEdits 由 foldNumericFormsWithOffsets 的偏移得到 src 到 dst 的改写，
+// 同一原文字符折叠出的字节为一处改写，被去掉的字符并入前一处
+func offsetEdits(src, dst string, offsets []int) []textEdit {
+	var edits []textEdit
+	for i := 0; i < len(dst); {
+		j := i + 1
+		for j < len(dst) && offsets[j] == offsets[i] {
+			j++
+		}
+		start, end := offsets[i], offsets[j]
+		if i == 0 {
+			start = 0
+		}
+		if src[start:end] != dst[i:j] {
+			edits = append(edits, textEdit{start: start, end: end, text: dst[i:j]})
+		}
+		i = j
+	}
+	if dst == "" && src != "" {
+		edits = append(edits, textEdit{start: 0, end: len(src)})
+	}
+	return edits
+}
+
+// diffAlignment 按字符做最短编辑，得到 a 到 b 的对齐
+func diffAlignment(a, b string) Alignment {
+	ar, br := []rune(a), []rune(b)
+	aOffsets, bOffsets := runeOffsets(a), runeOffsets(b)
+
+	var alignment Alignment
+	ai, bi := 0, 0
+	for _, common := range diffRunes(ar, br) {
+		if common[0] > ai || common[1] > bi {
+			alignment = append(alignment, AlignmentSpan{
+				SrcStart: aOffsets[ai], SrcEnd: aOffsets[common[0]],
+				DstStart: bOffsets[bi], DstEnd: bOffsets[common[1]],
+				Changed: true,
+			})
+		}
+		ai, bi = common[0]+common[2], common[1]+common[2]
+		alignment = append(alignment, AlignmentSpan{
+			SrcStart: aOffsets[common[0]], SrcEnd: aOffsets[ai],
+			DstStart: bOffsets[common[1]], DstEnd: bOffsets[bi],
+		})
+	}
+	if ai < len(ar) || bi < len(br) {
+		alignment = append(alignment, AlignmentSpan{
+			SrcStart: aOffsets[ai], SrcEnd: len(a),
+			DstStart: bOffsets[bi], DstEnd: len(b),
+			Changed: true,
+		})
+	}
+	return alignment
+}
+
+// runeOffsets 返回每个字符的字节偏移，末尾追加 len(s)
+func runeOffsets(s string) []int {
+	offsets := make([]int, 0, utf8.RuneCountInString(s)+1)
+	for i := range s {
+		offsets = append(offsets, i)
+	}
+	return append(offsets, len(s))
+}
+
+// diffRunes 用 Myers 算法求 a 到 b 的最短编辑，返回按顺序排列的相同片段 [a 起点, b 起点, 长度]
+func diffRunes(a, b []rune) [][3]int {
+	// 去掉相同的前缀和后缀
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var commons [][3]int
+	if prefix > 0 {
+		commons = append(commons, [3]int{0, 0, prefix})
+	}
+	for _, c := range myersCommon(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		commons = append(commons, [3]int{c[0] + prefix, c[1] + prefix, c[2]})
+	}
+	if suffix > 0 {
+		commons = append(commons, [3]int{len(a) - suffix, len(b) - suffix, suffix})
+	}
+	return commons
+}
+
+// myersCommon Myers 最短编辑的相同片段
+func myersCommon(a, b []rune) [][3]int {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return nil
+	}
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	// trace[d] 为第 d 步开始前 k ∈ [-d, d] 的 v
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return myersBacktrack(trace, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+// myersBacktrack 从终点回溯出相同片段
+func myersBacktrack(trace [][]int, n, m int) [][3]int {
+	var commons [][3]int
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || k != d && at(k-1) < at(k+1) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		// 编辑之后的位置，其后为相同片段
+		startX, startY := prevX, prevY+1
+		if prevK == k-1 {
+			startX, startY = prevX+1, prevY
+		}
+		if x > startX {
+			commons = append(commons, [3]int{startX, startY, x - startX})
+		}
+		x, y = prevX, prevY
+	}
+	if x > 0 {
+		commons = append(commons, [3]int{0, 0, x})
+	}
+	for i, j := 0, len(commons)-1; i < j; i, j = i+1, j-1 {
+		commons[i], commons[j] = commons[j], commons[i]
+	}
+	return commons
+}
+
+// composeAlignment 将 原文→中间结果 与 中间结果→新结果 的对齐合成为 原文→新结果
+func composeAlignment(base, step Alignment) Alignment {
+	var composed Alignment
+	for _, s := range step {
+		// base 中输出结束于 s 之后的第一个片段，之前的片段与 s 不相交
+		first := sort.Search(len(base), func(i int) bool { return base[i].DstEnd > s.SrcStart })
+		if !s.Changed {
+			// 相同片段沿用 base 的划分
+			for _, b := range base[first:] {
+				if b.DstStart >= s.SrcEnd {
+					break
+				}
+				lo, hi := max(b.DstStart, s.SrcStart), min(b.DstEnd, s.SrcEnd)
+				if lo >= hi {
+					continue
+				}
+				span := AlignmentSpan{
+					SrcStart: b.SrcStart, SrcEnd: b.SrcEnd,
+					DstStart: s.DstStart + lo - s.SrcStart, DstEnd: s.DstStart + hi - s.SrcStart,
+					Changed: b.Changed,
+				}
+				if !b.Changed {
+					span.SrcStart, span.SrcEnd = b.SrcStart+lo-b.DstStart, b.SrcStart+hi-b.DstStart
+				}
+				composed = append(composed, span)
+			}
+			continue
+		}
+
+		rest := base
+		if first < len(base) {
+			rest = base[first:]
+		}
+		srcStart, srcEnd := rest.Source(s.SrcStart, s.SrcEnd)
+		composed = append(composed, AlignmentSpan{
+			SrcStart: srcStart, SrcEnd: srcEnd,
+			DstStart: s.DstStart, DstEnd: s.DstEnd,
+			Changed: true,
+		})
+	}
+	return normalizeAlignment(composed, base.srcLen())
+}
+
+// srcLen 输入长度
+func (a Alignment) srcLen() int {
+	if len(a) == 0 {
+		return 0
+	}
+	return a[len(a)-1].SrcEnd
+}
+
+// normalizeAlignment 合并输入范围重叠的片段，并为未覆盖的输入补上删除片段
+func normalizeAlignment(spans Alignment, srcLen int) Alignment {
+	var result Alignment
+	srcPos := 0
+	for _, span := range spans {
+		if span.SrcStart > srcPos {
+			// 被删除的输入
+			dst := span.DstStart
+			result = appendSpan(result, AlignmentSpan{SrcStart: srcPos, SrcEnd: span.SrcStart, DstStart: dst, DstEnd: dst, Changed: true})
+		}
+		if n := len(result); n > 0 && span.SrcStart < result[n-1].SrcEnd {
+			last := &result[n-1]
+			last.SrcEnd = max(last.SrcEnd, span.SrcEnd)
+			last.DstEnd = span.DstEnd
+			last.Changed = true
+		} else {
+			result = appendSpan(result, span)
+		}
+		srcPos = max(srcPos, span.SrcEnd)
+	}
+	if srcPos < srcLen {
+		dst := 0
+		if n := len(result); n > 0 {
+			dst = result[n-1].DstEnd
+		}
+		result = appendSpan(result, AlignmentSpan{SrcStart: srcPos, SrcEnd: srcLen, DstStart: dst, DstEnd: dst, Changed: true})
+	}
+	return result
+}
+
+// appendSpan 追加片段，与前一个相同片段相邻时合并；
+// 纯插入或纯删除与相邻的改写片段合并，避免单独的插入片段使切分点两侧都取到或都漏掉插入的文本
+func appendSpan(spans Alignment, span AlignmentSpan) Alignment {
+	if span.SrcStart == span.SrcEnd && span.DstStart == span.DstEnd {
+		return spans
+	}
+	if n := len(spans); n > 0 {
+		last := &spans[n-1]
+		adjacent := last.SrcEnd == span.SrcStart && last.DstEnd == span.DstStart
+		if adjacent && !last.Changed && !span.Changed ||
+			adjacent && last.Changed && span.Changed && (last.isEmpty() || span.isEmpty()) {
+			last.SrcEnd, last.DstEnd = span.SrcEnd, span.DstEnd
+			return spans
+		}
+	}
+	return append(spans, span)
+}
+
+// isEmpty 输入或输出片段是否为空
+func (s AlignmentSpan) isEmpty() bool {
+	return s.SrcStart == s.SrcEnd || s.DstStart == s.DstEnd
+}
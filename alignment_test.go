@@ -0,0 +1,114 @@
+package gocn2an
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRunes(t *testing.T) {
+	testData := []struct {
+		a, b     string
+		expected [][3]int
+	}{
+		{"十一月十一日", "11月11日", [][3]int{{2, 2, 1}, {5, 5, 1}}},
+		{"abc", "abc", [][3]int{{0, 0, 3}}},
+		{"abc", "xyz", nil},
+		{"a-b", "a减b", [][3]int{{0, 0, 1}, {2, 2, 1}}},
+		{"", "abc", nil},
+	}
+
+	for _, item := range testData {
+		if got := diffRunes([]rune(item.a), []rune(item.b)); !reflect.DeepEqual(got, item.expected) {
+			t.Errorf("diffRunes(%q, %q) = %v, want %v", item.a, item.b, got, item.expected)
+		}
+	}
+}
+
+func TestTransformWithAlignment(t *testing.T) {
+	testData := []struct {
+		input, method string
+		expected      []string
+	}{
+		{"十一月十一日，半斤", "cn2an", []string{"十一=>11", "月", "十一=>11", "日，", "半=>0.5", "斤"}},
+		{"一心一意地等了两千五百年", "cn2an", []string{"一心一意地等了", "两千五百=>2500", "年"}},
+		{"下午三点半见", "cn2an", []string{"下午三点半=>15:30", "见"}},
+		{"3-2=1，|y|≥5%", "an2cn", []string{"3=>三", "-=>减", "2=>二", "==>等于", "1=>一", "，", "|y|=>y的绝对值", "≥=>大于等于", "5%=>百分之五"}},
+		{"14:30开会，Ⅻ号", "an2cn", []string{"14:30=>十四点三十分", "开会，", "Ⅻ=>十二", "号"}},
+		{"没有数字", "an2cn", []string{"没有数字"}},
+		{"负十负半十", "cn2an", []string{"负十=>-10", "负半", "十=>10"}},
+		{"2%2-1%", "an2cn", []string{"2%=>百分之二", "2=>二", "-=>减", "1%=>百分之一"}},
+		{"x^2 + y≤3", "an2cn", []string{"x", "^=>的", "2=>二次方", " ", "+=>加", " y", "≤=>小于等于", "3=>三"}},
+	}
+
+	transform := NewTransform()
+	for _, item := range testData {
+		output, alignment, err := transform.TransformWithAlignment(item.input, item.method)
+		if err != nil {
+			t.Errorf("TransformWithAlignment(%q, %s) error: %v", item.input, item.method, err)
+			continue
+		}
+		if expected, _ := transform.Transform(item.input, item.method); output != expected {
+			t.Errorf("TransformWithAlignment(%q, %s) = %q, want %q", item.input, item.method, output, expected)
+		}
+
+		var got []string
+		srcPos, dstPos := 0, 0
+		for _, span := range alignment {
+			if span.SrcStart != srcPos || span.DstStart != dstPos {
+				t.Errorf("TransformWithAlignment(%q, %s) span %+v is not contiguous", item.input, item.method, span)
+			}
+			srcPos, dstPos = span.SrcEnd, span.DstEnd
+			src, dst := item.input[span.SrcStart:span.SrcEnd], output[span.DstStart:span.DstEnd]
+			if span.Changed {
+				got = append(got, src+"=>"+dst)
+			} else {
+				got = append(got, src)
+			}
+		}
+		if srcPos != len(item.input) || dstPos != len(output) {
+			t.Errorf("TransformWithAlignment(%q, %s) covers [%d, %d), want [%d, %d)", item.input, item.method, srcPos, dstPos, len(item.input), len(output))
+		}
+		if !reflect.DeepEqual(got, item.expected) {
+			t.Errorf("TransformWithAlignment(%q, %s) = %v, want %v", item.input, item.method, got, item.expected)
+		}
+	}
+}
+
+func TestAlignmentSourceTarget(t *testing.T) {
+	input := "我等了一个半小时才到"
+	output, alignment, err := NewTransform().TransformWithAlignment(input, "cn2an")
+	if err != nil {
+		t.Fatalf("TransformWithAlignment(%q) error: %v", input, err)
+	}
+
+	// 输出中的 1.5小时 对应原文的 一个半小时
+	start, end := alignment.Source(9, 18)
+	if output[9:18] != "1.5小时" || input[start:end] != "一个半小时" {
+		t.Errorf("Source(9, 18) = %q, want %q", input[start:end], "一个半小时")
+	}
+	// 原文的 才到 对应输出的 才到
+	start, end = alignment.Target(24, 30)
+	if output[start:end] != "才到" {
+		t.Errorf("Target(24, 30) = %q, want %q", output[start:end], "才到")
+	}
+	// 原文的 半 落在改写片段内，取整个改写片段
+	start, end = alignment.Target(15, 18)
+	if output[start:end] != "1.5" {
+		t.Errorf("Target(15, 18) = %q, want %q", output[start:end], "1.5")
+	}
+}
+
+func TestAppendSpanMergesInsertions(t *testing.T) {
+	var alignment Alignment
+	alignment = appendSpan(alignment, AlignmentSpan{SrcStart: 0, SrcEnd: 0, DstStart: 0, DstEnd: 3, Changed: true})
+	alignment = appendSpan(alignment, AlignmentSpan{SrcStart: 0, SrcEnd: 3, DstStart: 3, DstEnd: 6, Changed: true})
+	alignment = appendSpan(alignment, AlignmentSpan{SrcStart: 3, SrcEnd: 6, DstStart: 6, DstEnd: 6, Changed: true})
+	alignment = appendSpan(alignment, AlignmentSpan{SrcStart: 6, SrcEnd: 9, DstStart: 6, DstEnd: 9})
+	expected := Alignment{
+		{SrcStart: 0, SrcEnd: 6, DstStart: 0, DstEnd: 6, Changed: true},
+		{SrcStart: 6, SrcEnd: 9, DstStart: 6, DstEnd: 9},
+	}
+	if !reflect.DeepEqual(alignment, expected) {
+		t.Errorf("appendSpan = %+v, want %+v", alignment, expected)
+	}
+}
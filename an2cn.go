@@ -10,25 +10,49 @@ import (
 
 // An2Cn 阿拉伯数字转中文数字的转换器
 type An2Cn struct {
-	allNum    string
-	numberLow map[int]string
-	numberUp  map[int]string
-	modeList  []string
+	allNum       string
+	numberLow    map[int]string
+	numberUp     map[int]string
+	modeList     []string
+	traditional  bool
+	contractions bool
+}
+
+// An2CnOption An2Cn 的可选配置
+type An2CnOption func(*An2Cn)
+
+// WithTraditionalScript 以繁体字输出（萬、億、貳、參、陸、點、負）
+func WithTraditionalScript() An2CnOption {
+	return func(a *An2Cn) {
+		a.traditional = true
+	}
+}
+
+// WithContractions 在 low 模式下使用合文写法（廿一、卅五、卌）
+func WithContractions() An2CnOption {
+	return func(a *An2Cn) {
+		a.contractions = true
+	}
 }
 
 // NewAn2Cn 创建新的阿拉伯数字到中文转换器
-func NewAn2Cn() *An2Cn {
-	return &An2Cn{
+func NewAn2Cn(opts ...An2CnOption) *An2Cn {
+	a := &An2Cn{
 		allNum:    "0123456789",
 		numberLow: NumberLowAN2CN,
 		numberUp:  NumberUpAN2CN,
-		modeList:  []string{"low", "up", "rmb", "direct"},
+		modeList:  []string{"low", "up", "rmb", "direct", "ja", "ja_daiji", "ja_yen"},
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // An2cn 阿拉伯数字转中文数字的主函数
 // inputs: 数字字符串或数字
-// mode: low(小写), up(大写), rmb(人民币), direct(直接转换)
+// mode: low(小写), up(大写), rmb(人民币), direct(直接转换),
+// ja(日文汉数字), ja_daiji(日文大字), ja_yen(日文金额，如 金壱萬円也)
 func (a *An2Cn) An2cn(inputs interface{}, mode string) (string, error) {
 	if inputs == nil || inputs == "" {
 		return "", errors.New("输入数据为空")
@@ -72,6 +96,17 @@ func (a *An2Cn) An2cn(inputs interface{}, mode string) (string, error) {
 
 	var output string
 
+	if isJaMode(mode) {
+		jaOut, err := a.jaConvert(inputStr, mode)
+		if err != nil {
+			return "", err
+		}
+		if sign != "" {
+			sign = "マイナス"
+		}
+		return sign + jaOut, nil
+	}
+
 	if mode == "direct" {
 		output = a.directConvert(inputStr)
 	} else {
@@ -160,9 +195,27 @@ func (a *An2Cn) An2cn(inputs interface{}, mode string) (string, error) {
 		}
 	}
 
+	if a.contractions && mode == "low" {
+		for from, to := range ContractionAN2CN {
+			output = strings.ReplaceAll(output, from, to)
+		}
+	}
+	if a.traditional {
+		return toTraditionalScript(sign + output), nil
+	}
 	return sign + output, nil
 }
 
+// toTraditionalScript 将简体数字用字转换为繁体
+func toTraditionalScript(s string) string {
+	return strings.Map(func(r rune) rune {
+		if mapped, ok := TraditionalAN2CN[r]; ok {
+			return mapped
+		}
+		return r
+	}, s)
+}
+
 // numberToString 将数字转换为字符串（处理科学记数法）
 func (a *An2Cn) numberToString(number float64) string {
 	str := strconv.FormatFloat(number, 'f', -1, 64)
@@ -178,7 +231,7 @@ func (a *An2Cn) numberToString(number float64) string {
 
 // preprocess 数据预处理
 func (a *An2Cn) preprocess(s string) string {
-	return normalizeText(s)
+	return normalizeText(foldNumericForms(s, false))
 }
 
 // checkInputsIsValid 检查输入数据是否有效
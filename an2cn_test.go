@@ -164,3 +164,57 @@ func TestAn2cnError(t *testing.T) {
 		}
 	}
 }
+
+func TestAn2cnTraditional(t *testing.T) {
+	testData := map[string]map[interface{}]string{
+		"low": {
+			30000:     "三萬",
+			100020000: "一億零二萬",
+			-3.5:      "負三點五",
+		},
+		"up": {
+			30000: "參萬",
+			26:    "貳拾陸",
+		},
+		"rmb": {
+			4000000: "肆佰萬元整",
+		},
+	}
+
+	a := NewAn2Cn(WithTraditionalScript())
+	for mode, cases := range testData {
+		for input, expected := range cases {
+			result, err := a.An2cn(input, mode)
+			if err != nil {
+				t.Errorf("An2cn(%v, %s) error: %v", input, mode, err)
+				continue
+			}
+			if result != expected {
+				t.Errorf("An2cn(%v, %s) = %s, want %s", input, mode, result, expected)
+			}
+		}
+	}
+}
+
+func TestAn2cnContractions(t *testing.T) {
+	testData := map[interface{}]string{
+		21:  "廿一",
+		35:  "卅五",
+		40:  "卌",
+		120: "一百廿",
+		12:  "十二",
+		2.5: "二点五",
+	}
+
+	a := NewAn2Cn(WithContractions())
+	for input, expected := range testData {
+		result, err := a.An2cn(input, "low")
+		if err != nil {
+			t.Errorf("An2cn(%v, low) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("An2cn(%v, low) = %s, want %s", input, result, expected)
+		}
+	}
+}
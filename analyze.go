@@ -0,0 +1,161 @@
+package gocn2an
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Entity 句子中识别出的数字实体
+type Entity struct {
+	// Category 类别：cardinal、ordinal、money、date、time、duration、lunar_date、quarter、fraction、percent、celsius
+	Category string
+	// Text 原文片段
+	Text string
+	// Start、End 为原文中的字节偏移，RuneStart、RuneEnd 为字符偏移
+	Start     int
+	End       int
+	RuneStart int
+	RuneEnd   int
+	// Value 规范化的值，如 2500、2024-03-05、15:30、1h30m0s、25%
+	Value string
+	// Replacement 句子转换（cn2an）输出的写法
+	Replacement string
+}
+
+var (
+	// moneyUnitRe 金额单位
+	moneyUnitRe = regexp.MustCompile(`^(?:美元|欧元|英镑|日元|韩元|港元|港币|块钱|元|块|塊|圆|圓|角|毛)`)
+	// numericPrefixRe 替换结果开头的数值
+	numericPrefixRe = regexp.MustCompile(`^-?[0-9]+(?:\.[0-9]+)?`)
+	// foldedNumberRe 折叠后的阿拉伯数字
+	foldedNumberRe = regexp.MustCompile(`[0-9]+(?:\.[0-9]+)?`)
+)
+
+// Analyze 识别句子中的中文数字实体，返回按位置排序的实体；
+// 识别规则与 Transform(inputs, "cn2an") 相同，保护词表和分词器判定为非数词的词语不会出现在结果中
+func (t *Transform) Analyze(inputs string) []Entity {
+	folded, offsets := foldNumericFormsWithOffsets(inputs, false, true)
+
+	// 已被占用的片段：保护词语及先前阶段的实体
+	var claimed []protectedSpan
+	claimed = append(claimed, t.lexiconSpans(folded)...)
+	if t.segmenter != nil {
+		claimed = append(claimed, t.segmenterSpans(folded)...)
+	}
+	overlaps := func(start, end int) bool {
+		for _, span := range claimed {
+			if start < span.end && span.start < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	var entities []Entity
+	for _, stage := range t.cn2anStages {
+		for _, loc := range stage.re.FindAllStringIndex(folded, -1) {
+			start, end := loc[0], loc[1]
+			if start == end || overlaps(start, end) || stage.accept != nil && !stage.accept(folded, start, end) {
+				continue
+			}
+			match := folded[start:end]
+			replacement, err := t.subUtil(match, "cn2an", stage.subMode)
+			if err != nil || replacement == match {
+				continue
+			}
+			claimed = append(claimed, protectedSpan{start: start, end: end})
+			entities = append(entities, Entity{
+				Category:    stage.category,
+				Start:       start,
+				End:         end,
+				Value:       t.entityValue(stage.subMode, match, replacement),
+				Replacement: replacement,
+			})
+		}
+	}
+
+	// ⑳、Ⅻ、全角数字 等只由折叠改写、其后各阶段不再转换的数字
+	for _, loc := range foldedNumberRe.FindAllStringIndex(folded, -1) {
+		start, end := loc[0], loc[1]
+		match := folded[start:end]
+		if overlaps(start, end) || inputs[offsets[start]:foldedEndOffset(offsets, end)] == match {
+			continue
+		}
+		entities = append(entities, Entity{
+			Category:    "cardinal",
+			Start:       start,
+			End:         end,
+			Value:       match,
+			Replacement: match,
+		})
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].Start < entities[j].Start
+	})
+	for i := range entities {
+		e := &entities[i]
+		if e.Category == "cardinal" {
+			classifyCardinal(folded, e)
+		}
+		// 换回原文中的位置
+		e.Start = offsets[e.Start]
+		e.End = foldedEndOffset(offsets, e.End)
+		e.Text = inputs[e.Start:e.End]
+		e.RuneStart = utf8.RuneCountInString(inputs[:e.Start])
+		e.RuneEnd = e.RuneStart + utf8.RuneCountInString(e.Text)
+	}
+	return entities
+}
+
+// entityValue 实体的规范化值
+func (t *Transform) entityValue(subMode, match, replacement string) string {
+	switch subMode {
+	case "number", "half":
+		if value := numericPrefixRe.FindString(replacement); value != "" {
+			return value
+		}
+	case "date":
+		// 未写年份的日期保留原写法
+		if d, err := t.cn2an.ParseDate(match); err == nil && d.Has(DateHasYear) {
+			return FormatISODate(d)
+		}
+	case "duration":
+		// 不含年、月的时长用 time.Duration 写法，如 1h30m0s
+		if d, err := t.cn2an.ParseDuration(match); err == nil {
+			return d.String()
+		}
+	case "ganzhi":
+		// 甲辰年（2024）
+		if i := strings.Index(replacement, "（"); i != -1 {
+			return strings.TrimSuffix(replacement[i+len("（"):], "）")
+		}
+	}
+	return replacement
+}
+
+// classifyCardinal 前有「第」的基数改为序数，后接金额单位的改为金额，并扩展实体范围
+func classifyCardinal(s string, e *Entity) {
+	if strings.HasSuffix(s[:e.Start], "第") {
+		e.Category = "ordinal"
+		e.Start -= len("第")
+		e.Replacement = "第" + e.Replacement
+		return
+	}
+	if unit := moneyUnitRe.FindString(s[e.End:]); unit != "" {
+		e.Category = "money"
+		e.End += len(unit)
+		e.Replacement += unit
+	}
+}
+
+// foldedEndOffset 将 foldNumericForms 结果中的结束位置换回原文位置
+func foldedEndOffset(offsets []int, end int) int {
+	// 结束位置落在同一原文字符折叠出的多个字节之间时，取该字符之后
+	for end < len(offsets)-1 && end > 0 && offsets[end] == offsets[end-1] {
+		end++
+	}
+	return offsets[end]
+}
@@ -0,0 +1,80 @@
+package gocn2an
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	input := "二〇二四年三月五日下午三点半，第三名拿了一百元奖金和百分之二十的分成，三分之一的人等了一个半小时"
+	expected := []Entity{
+		{Category: "date", Text: "二〇二四年三月五日", Start: 0, End: 27, RuneStart: 0, RuneEnd: 9, Value: "2024-03-05", Replacement: "2024年3月5日"},
+		{Category: "time", Text: "下午三点半", Start: 27, End: 42, RuneStart: 9, RuneEnd: 14, Value: "15:30", Replacement: "15:30"},
+		{Category: "ordinal", Text: "第三", Start: 45, End: 51, RuneStart: 15, RuneEnd: 17, Value: "3", Replacement: "第3"},
+		{Category: "money", Text: "一百元", Start: 60, End: 69, RuneStart: 20, RuneEnd: 23, Value: "100", Replacement: "100元"},
+		{Category: "percent", Text: "百分之二十", Start: 78, End: 93, RuneStart: 26, RuneEnd: 31, Value: "20%", Replacement: "20%"},
+		{Category: "fraction", Text: "三分之一", Start: 105, End: 117, RuneStart: 35, RuneEnd: 39, Value: "1/3", Replacement: "1/3"},
+		{Category: "duration", Text: "一个半小时", Start: 129, End: 144, RuneStart: 43, RuneEnd: 48, Value: "1h30m0s", Replacement: "1.5小时"},
+	}
+
+	result := NewTransform().Analyze(input)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Analyze(%q) =\n%+v\nwant\n%+v", input, result, expected)
+	}
+	for _, e := range result {
+		if input[e.Start:e.End] != e.Text {
+			t.Errorf("Analyze(%q) entity %q has offsets [%d, %d)", input, e.Text, e.Start, e.End)
+		}
+	}
+}
+
+func TestAnalyzeCategories(t *testing.T) {
+	testData := map[string][]string{
+		"温度二十五摄氏度":   {"celsius:25℃"},
+		"买了两千五百个":    {"cardinal:2500"},
+		"今年第三季度营收":   {"quarter:Q3"},
+		"一千万美元":      {"money:10000000"},
+		"全国统一，一心一意":  nil,
+		"两岸三地":       {"cardinal:3"},
+		"贰拾元":        {"money:20"},
+		"他十一假期去了九寨沟": nil,
+		"⑤号楼住了三户人家":  {"cardinal:5", "cardinal:3"},
+		"正月初一吃饺子":    {"lunar_date:农历1月1日"},
+		"三月五日开学":     {"date:3月5日"},
+	}
+
+	transform := NewTransform()
+	for input, expected := range testData {
+		var got []string
+		for _, e := range transform.Analyze(input) {
+			got = append(got, e.Category+":"+e.Value)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Analyze(%q) = %v, want %v", input, got, expected)
+		}
+	}
+}
+
+func TestAnalyzeFoldedOffsets(t *testing.T) {
+	// Ⅻ 折叠为 12，偏移仍指向原文
+	testData := map[string][]Entity{
+		"第Ⅻ届三月五日开幕": {
+			{Category: "ordinal", Text: "第Ⅻ", Start: 0, End: 6, RuneStart: 0, RuneEnd: 2, Value: "12", Replacement: "第12"},
+			{Category: "date", Text: "三月五日", Start: 9, End: 21, RuneStart: 3, RuneEnd: 7, Value: "3月5日", Replacement: "3月5日"},
+		},
+		"⑳个人": {
+			{Category: "cardinal", Text: "⑳", Start: 0, End: 3, RuneStart: 0, RuneEnd: 1, Value: "20", Replacement: "20"},
+		},
+		"买了２０元": {
+			{Category: "money", Text: "２０元", Start: 6, End: 15, RuneStart: 2, RuneEnd: 5, Value: "20", Replacement: "20元"},
+		},
+	}
+
+	transform := NewTransform()
+	for input, expected := range testData {
+		result := transform.Analyze(input)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Analyze(%q) = %+v, want %+v", input, result, expected)
+		}
+	}
+}
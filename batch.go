@@ -0,0 +1,90 @@
+package gocn2an
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// BatchOptions 批量转换选项
+type BatchOptions struct {
+	// Workers 并发数，小于等于 0 时取 runtime.GOMAXPROCS(0)
+	Workers int
+}
+
+// Cn2anBatch 并发执行 Cn2an，结果和错误与 inputs 一一对应；
+// ctx 取消后不再开始新的转换，未转换的输入的错误为 ctx.Err()。
+// Cn2An 创建后只读，可在多个 goroutine 间共享
+func (c *Cn2An) Cn2anBatch(ctx context.Context, inputs []string, mode string, opts BatchOptions) ([]float64, []error) {
+	outputs := make([]float64, len(inputs))
+	errs := runBatch(ctx, len(inputs), opts.Workers, func(i int) (err error) {
+		outputs[i], err = c.Cn2an(inputs[i], mode)
+		return err
+	})
+	return outputs, errs
+}
+
+// An2cnBatch 并发执行 An2cn，inputs 的元素与 An2cn 的参数相同，结果和错误与 inputs 一一对应；
+// ctx 取消后不再开始新的转换，未转换的输入的错误为 ctx.Err()。
+// An2Cn 创建后只读，可在多个 goroutine 间共享
+func (a *An2Cn) An2cnBatch(ctx context.Context, inputs []interface{}, mode string, opts BatchOptions) ([]string, []error) {
+	outputs := make([]string, len(inputs))
+	errs := runBatch(ctx, len(inputs), opts.Workers, func(i int) (err error) {
+		outputs[i], err = a.An2cn(inputs[i], mode)
+		return err
+	})
+	return outputs, errs
+}
+
+// TransformBatch 并发执行 Transform，结果和错误与 inputs 一一对应；
+// ctx 取消后不再开始新的转换，未转换的输入的错误为 ctx.Err()。
+// Transform 创建后只读（自定义 Segmenter 需自行保证并发安全），可在多个 goroutine 间共享
+func (t *Transform) TransformBatch(ctx context.Context, inputs []string, method string, opts BatchOptions) ([]string, []error) {
+	outputs := make([]string, len(inputs))
+	errs := runBatch(ctx, len(inputs), opts.Workers, func(i int) (err error) {
+		outputs[i], err = t.Transform(inputs[i], method)
+		return err
+	})
+	return outputs, errs
+}
+
+// runBatch 以 workers 个 goroutine 对 [0, n) 执行 do，返回每项的错误
+func runBatch(ctx context.Context, n, workers int, do func(i int) error) []error {
+	errs := make([]error, n)
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = do(i)
+			}
+		}()
+	}
+
+	i := 0
+feed:
+	for ; i < n && ctx.Err() == nil; i++ {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	// 取消后未开始的输入
+	for ; i < n; i++ {
+		errs[i] = ctx.Err()
+	}
+	return errs
+}
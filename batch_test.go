@@ -0,0 +1,75 @@
+package gocn2an
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTransformBatch(t *testing.T) {
+	transform := NewTransform()
+	inputs := make([]string, 200)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("第%d章共有一百二十个，下午三点半见", i)
+	}
+
+	for _, workers := range []int{0, 1, 8} {
+		outputs, errs := transform.TransformBatch(context.Background(), inputs, "cn2an", BatchOptions{Workers: workers})
+		for i, input := range inputs {
+			expected, _ := transform.Transform(input, "cn2an")
+			if outputs[i] != expected || errs[i] != nil {
+				t.Errorf("TransformBatch(workers=%d)[%d] = %q, %v, want %q", workers, i, outputs[i], errs[i], expected)
+			}
+		}
+	}
+
+	_, errs := transform.TransformBatch(context.Background(), []string{"一"}, "abc", BatchOptions{})
+	if errs[0] == nil {
+		t.Error("TransformBatch with invalid method should return error")
+	}
+}
+
+func TestCn2anAn2cnBatch(t *testing.T) {
+	ctx := context.Background()
+	outputs, errs := NewCn2An().Cn2anBatch(ctx, []string{"一百二十三", "abc", "负五点五"}, "normal", BatchOptions{Workers: 2})
+	if outputs[0] != 123 || errs[0] != nil || errs[1] == nil || outputs[2] != -5.5 || errs[2] != nil {
+		t.Errorf("Cn2anBatch = %v, %v", outputs, errs)
+	}
+
+	strs, errs := NewAn2Cn().An2cnBatch(ctx, []interface{}{"123", 5.5, "abc"}, "low", BatchOptions{})
+	if strs[0] != "一百二十三" || errs[0] != nil || strs[1] != "五点五" || errs[2] == nil {
+		t.Errorf("An2cnBatch = %q, %v", strs, errs)
+	}
+}
+
+func TestBatchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	inputs := []string{"一", "二", "三"}
+	_, errs := NewTransform().TransformBatch(ctx, inputs, "cn2an", BatchOptions{Workers: 2})
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("TransformBatch(cancelled)[%d] error = %v, want context.Canceled", i, err)
+		}
+	}
+
+	// 转换过程中取消：已开始的完成，其余返回 ctx.Err()；取消时已在发送的一项仍可能被执行
+	ctx, cancel = context.WithCancel(context.Background())
+	count := 0
+	errs = runBatch(ctx, 100, 1, func(i int) error {
+		count++
+		if i == 9 {
+			cancel()
+		}
+		return nil
+	})
+	if count < 10 || count > 11 {
+		t.Errorf("runBatch ran %d items after cancel, want 10 or 11", count)
+	}
+	for i, err := range errs {
+		if (i < count) != (err == nil) {
+			t.Errorf("runBatch[%d] error = %v", i, err)
+		}
+	}
+}
@@ -23,14 +23,26 @@ type Cn2An struct {
 	pattern1        *regexp.Regexp
 	ptnAllNum       *regexp.Regexp
 	ptnSpeakingMode *regexp.Regexp
+	slangUnits      map[string]float64
+	slangPattern    *regexp.Regexp
 }
 
+// Cn2AnOption Cn2An 的可选配置
+type Cn2AnOption func(*Cn2An)
+
 // NewCn2An 创建新的中文到阿拉伯数字转换器
-func NewCn2An() *Cn2An {
+func NewCn2An(opts ...Cn2AnOption) *Cn2An {
 	c := &Cn2An{
 		strictCNNumber: StrictCNNumber,
 		normalCNNumber: NormalCNNumber,
-		modeList:       []string{"strict", "normal", "smart"},
+		modeList:       []string{"strict", "normal", "smart", "ja"},
+		slangUnits:     make(map[string]float64, len(SlangUnitCN2AN)),
+	}
+	for suffix, val := range SlangUnitCN2AN {
+		c.slangUnits[suffix] = val
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
 
 	// 构建 allNum 和 allUnit
@@ -42,10 +54,14 @@ func NewCn2An() *Cn2An {
 	}
 
 	// 构建 checkKeyDict
+	contractionKeys := ""
+	for r := range ContractionCN2AN {
+		contractionKeys += string(r)
+	}
 	c.checkKeyDict = map[string]string{
-		"strict": c.buildCheckKey(c.strictCNNumber) + "点负",
-		"normal": c.buildCheckKey(c.normalCNNumber) + "点负",
-		"smart":  c.buildCheckKey(c.normalCNNumber) + "点负" + "01234567890.-",
+		"strict": c.buildCheckKey(c.strictCNNumber) + "点负" + contractionKeys,
+		"normal": c.buildCheckKey(c.normalCNNumber) + "点负" + contractionKeys,
+		"smart":  c.buildCheckKey(c.normalCNNumber) + "点负" + contractionKeys + "01234567890.-",
 	}
 
 	// 构建模式字典
@@ -55,10 +71,13 @@ func NewCn2An() *Cn2An {
 	c.ac = NewAn2Cn()
 
 	// 编译正则表达式
-	c.yjfPattern = regexp.MustCompile(fmt.Sprintf(`^.*?[元圆][%s]角([%s]分)?$`, c.allNum, c.allNum))
+	// 没有元、块时角须用大写数字，避免 三角 => 0.3
+	upperDigit := "壹贰叁肆伍陆柒捌玖" + variantRunesOf("贰叁陆")
+	c.yjfPattern = regexp.MustCompile(fmt.Sprintf(`^(.*?[元圆块][%s]|[负負]?[%s])[角毫毛]([%s]分)?$`, c.allNum, upperDigit, c.allNum))
 	c.pattern1 = regexp.MustCompile(fmt.Sprintf(`^-?\d+(\.\d+)?[%s]?$`, c.allUnit))
 	c.ptnAllNum = regexp.MustCompile(fmt.Sprintf(`^[%s]+$`, c.allNum))
 	c.ptnSpeakingMode = regexp.MustCompile(fmt.Sprintf(`^([%s]{0,2}[%s])+[%s]$`, c.allNum, c.allUnit, c.allNum))
+	c.slangPattern = regexp.MustCompile(`^` + c.slangSuffixPattern() + `\+?$`)
 
 	return c
 }
@@ -74,7 +93,7 @@ func (c *Cn2An) buildCheckKey(cnNumber map[string]string) string {
 
 // Cn2an 中文数字转阿拉伯数字的主函数
 // inputs: 中文数字字符串
-// mode: strict(严格), normal(正常), smart(智能)
+// mode: strict(严格), normal(正常), smart(智能), ja(日文汉数字)
 func (c *Cn2An) Cn2an(inputs string, mode string) (float64, error) {
 	if inputs == "" {
 		return 0, errors.New("输入数据为空")
@@ -87,8 +106,16 @@ func (c *Cn2An) Cn2an(inputs string, mode string) (float64, error) {
 	// 数据预处理
 	inputs = c.preprocess(inputs)
 
-	// 特殊转化 廿
-	inputs = strings.ReplaceAll(inputs, "廿", "二十")
+	if mode == "ja" {
+		return c.jaCn2an(inputs)
+	}
+
+	// smart 模式下的网络用语数量级：5w、1.2kw、5 million
+	if mode == "smart" {
+		if val, ok := c.slangConvert(inputs); ok {
+			return val, nil
+		}
+	}
 
 	// 检查输入数据是否有效
 	sign, integerData, decimalData, isAllNum, specialValue, hasSpecialValue, err := c.checkInputDataIsValid(inputs, mode)
@@ -152,7 +179,7 @@ func (c *Cn2An) Cn2an(inputs string, mode string) (float64, error) {
 
 // preprocess 数据预处理（简化版，实际应该包括繁体转简体、全角转半角）
 func (c *Cn2An) preprocess(s string) string {
-	return normalizeText(s)
+	return normalizeText(foldNumericForms(s, true))
 }
 
 // getPattern 获取正则表达式模式
@@ -160,9 +187,9 @@ func (c *Cn2An) getPattern() map[string]map[string]*regexp.Regexp {
 	// 整数严格检查
 	_0 := "[零]"
 	_1_9 := "[一二三四五六七八九]"
-	_10_99 := fmt.Sprintf("%s?[十]%s?", _1_9, _1_9)
+	_10_99 := fmt.Sprintf("(%s?[十]|[廿卅卌])%s?", _1_9, _1_9)
 	_1_99 := fmt.Sprintf("(%s|%s)", _10_99, _1_9)
-	_100_999 := fmt.Sprintf("(%s[百]([零]%s)?|%s[百]%s)", _1_9, _1_9, _1_9, _10_99)
+	_100_999 := fmt.Sprintf("((%s[百]|皕)([零]%s)?|(%s[百]|皕)%s)", _1_9, _1_9, _1_9, _10_99)
 	_1_999 := fmt.Sprintf("(%s|%s)", _100_999, _1_99)
 	_1000_9999 := fmt.Sprintf("(%s[千]([零]%s)?|%s[千]%s)", _1_9, _1_99, _1_9, _100_999)
 	_1_9999 := fmt.Sprintf("(%s|%s)", _1000_9999, _1_999)
@@ -229,19 +256,25 @@ func (c *Cn2An) checkInputDataIsValid(checkData, mode string) (int, string, stri
 		}
 	}
 
-	// 在非严格模式下去除元、圆
+	// 在非严格模式下去除元、圆、块
 	if mode != "strict" {
-		normalStopWords := []string{"圆", "元"}
+		normalStopWords := []string{"圆", "元", "块"}
 		for _, word := range normalStopWords {
 			checkData = strings.TrimSuffix(checkData, word)
 		}
 	}
 
-	// 处理元角分
+	// 处理元角分（港台写法：圓、塊、毫）
 	if c.yjfPattern.MatchString(originalData) {
-		checkData = strings.ReplaceAll(checkData, "元", "点")
-		checkData = strings.ReplaceAll(checkData, "角", "")
-		checkData = strings.ReplaceAll(checkData, "分", "")
+		if !strings.ContainsAny(checkData, "元圆块") {
+			if strings.HasPrefix(checkData, "负") {
+				checkData = "负零点" + strings.TrimPrefix(checkData, "负")
+			} else {
+				checkData = "零点" + checkData
+			}
+		}
+		checkData = strings.NewReplacer("元", "点", "圆", "点", "块", "点").Replace(checkData)
+		checkData = strings.NewReplacer("角", "", "毫", "", "毛", "", "分", "").Replace(checkData)
 	}
 
 	// 处理特殊问法
@@ -420,11 +453,16 @@ func (c *Cn2An) integerConvert(integerData string) (int64, error) {
 	for i := len(runes) - 1; i >= 0; i-- {
 		cnNum := runes[i]
 
+		contraction, isContraction := ContractionCN2AN[cnNum]
+
 		// 数值
 		if num, ok := NumberCN2AN[cnNum]; ok {
 			output += int64(num) * unit
-		} else if unitVal, ok := UnitCN2AN[cnNum]; ok {
-			// 单位
+		} else if unitVal, ok := UnitCN2AN[cnNum]; ok || isContraction {
+			// 单位（合文如廿 = 二 × 十）
+			if isContraction {
+				unitVal = contraction[1]
+			}
 			unit = unitVal
 
 			// 判断万、亿
@@ -441,8 +479,10 @@ func (c *Cn2An) integerConvert(integerData string) (int64, error) {
 				unit = unit * tenThousandUnit
 			}
 
-			// 如果是最后一个字符且是单位，需要加上单位值
-			if i == 0 {
+			if isContraction {
+				output += contraction[0] * unit
+			} else if i == 0 {
+				// 如果是最后一个字符且是单位，需要加上单位值
 				output += unit
 			}
 		} else {
@@ -196,3 +196,75 @@ func TestCn2anError(t *testing.T) {
 		}
 	}
 }
+
+func TestCn2anTraditional(t *testing.T) {
+	testData := map[string][2]interface{}{
+		"參萬":    {"strict", 30000.0},
+		"叄":     {"strict", 3.0},
+		"柒仟":    {"strict", 7000.0},
+		"肆佰萬圓整": {"strict", 4000000.0},
+		"伍角":    {"normal", 0.5},
+		"壹圓伍角":  {"normal", 1.5},
+		"三塊五毛":  {"normal", 3.5},
+		"壹元伍毫":  {"normal", 1.5},
+		"負三點五":  {"strict", -3.5},
+		"一億零兩萬": {"normal", 100020000.0},
+		"貮拾":    {"strict", 20.0},
+		"○":     {"normal", 0.0},
+		"陸":     {"strict", 6.0},
+		"參拾":    {"strict", 30.0},
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		mode := expected[0].(string)
+		result, err := c.Cn2an(input, mode)
+		if err != nil {
+			t.Errorf("Cn2an(%q, %s) error: %v", input, mode, err)
+			continue
+		}
+		if result != expected[1].(float64) {
+			t.Errorf("Cn2an(%q, %s) = %f, want %f", input, mode, result, expected[1])
+		}
+	}
+}
+
+func TestCn2anContractions(t *testing.T) {
+	testData := map[string]float64{
+		"廿":   20,
+		"廿一":  21,
+		"卅":   30,
+		"卅五":  35,
+		"卌":   40,
+		"卌二":  42,
+		"皕":   200,
+		"皕零五": 205,
+		"一百廿": 120,
+		"廿万":  200000,
+		"卄三":  23,
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.Cn2an(input, "strict")
+		if err != nil {
+			t.Errorf("Cn2an(%q, strict) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Cn2an(%q, strict) = %f, want %f", input, result, expected)
+		}
+	}
+}
+
+func TestCn2anBareJiaoFen(t *testing.T) {
+	// 小写数字的角、分 只在跟随元、块时按金额解析
+	c := NewCn2An()
+	for _, input := range []string{"三角", "五分"} {
+		for _, mode := range []string{"strict", "normal"} {
+			if result, err := c.Cn2an(input, mode); err == nil {
+				t.Errorf("Cn2an(%q, %s) = %v, want error", input, mode, result)
+			}
+		}
+	}
+}
@@ -38,6 +38,33 @@ var UnitCN2AN = map[rune]int64{
 	'亿': 100000000,
 }
 
+// ContractionCN2AN 合文数字到「数字, 单位」的映射
+var ContractionCN2AN = map[rune][2]int64{
+	'廿': {2, 10},
+	'卅': {3, 10},
+	'卌': {4, 10},
+	'皕': {2, 100},
+}
+
+// ContractionAN2CN 十位合文写法（廿一、卅五）
+var ContractionAN2CN = map[string]string{
+	"二十": "廿",
+	"三十": "卅",
+	"四十": "卌",
+}
+
+// SlangUnitCN2AN 网络用语及英文数量级后缀（不区分大小写），用于 smart 模式
+var SlangUnitCN2AN = map[string]float64{
+	"k":        1000,
+	"w":        10000,
+	"kw":       10000000,
+	"m":        1000000,
+	"b":        1000000000,
+	"thousand": 1000,
+	"million":  1000000,
+	"billion":  1000000000,
+}
+
 // UnitLowAN2CN 数值到小写中文单位的映射
 var UnitLowAN2CN = map[int64]string{
 	10:        "十",
@@ -75,6 +102,20 @@ var NumberUpAN2CN = map[int]string{
 	9: "玖",
 }
 
+// TraditionalAN2CN 简体数字用字到繁体的映射
+var TraditionalAN2CN = map[rune]rune{
+	'万': '萬',
+	'亿': '億',
+	'贰': '貳',
+	'叁': '參',
+	'陆': '陸',
+	'点': '點',
+	'负': '負',
+	'两': '兩',
+	'腊': '臘',
+	'闰': '閏',
+}
+
 // UnitLowOrderAN2CN 按位置的小写单位（从右到左）
 var UnitLowOrderAN2CN = []string{
 	"",   // 0: 个位
@@ -152,3 +193,183 @@ var NormalCNNumber = map[string]string{
 	"万": "万",
 	"亿": "亿",
 }
+
+// NumberJACN2AN 日文汉数字（含大字）到阿拉伯数字的映射
+var NumberJACN2AN = map[rune]int{
+	'〇': 0,
+	'零': 0,
+	'一': 1,
+	'壱': 1,
+	'壹': 1,
+	'弌': 1,
+	'二': 2,
+	'弐': 2,
+	'贰': 2,
+	'弍': 2,
+	'三': 3,
+	'参': 3,
+	'叁': 3,
+	'弎': 3,
+	'四': 4,
+	'肆': 4,
+	'五': 5,
+	'伍': 5,
+	'六': 6,
+	'陆': 6,
+	'七': 7,
+	'漆': 7,
+	'柒': 7,
+	'八': 8,
+	'捌': 8,
+	'九': 9,
+	'玖': 9,
+}
+
+// UnitJACN2AN 日文汉数字单位到数值的映射
+var UnitJACN2AN = map[rune]int64{
+	'十': 10,
+	'拾': 10,
+	'百': 100,
+	'佰': 100,
+	'陌': 100,
+	'千': 1000,
+	'仟': 1000,
+	'阡': 1000,
+	'万': 10000,
+	'亿': 100000000,
+	'兆': 1000000000000,
+}
+
+// NumberJaAN2CN 数字到日文汉数字的映射
+var NumberJaAN2CN = map[int]string{
+	0: "〇",
+	1: "一",
+	2: "二",
+	3: "三",
+	4: "四",
+	5: "五",
+	6: "六",
+	7: "七",
+	8: "八",
+	9: "九",
+}
+
+// NumberJaDaijiAN2CN 数字到日文大字的映射（法定大字仅壱弐参拾，其余沿用常用汉字）
+var NumberJaDaijiAN2CN = map[int]string{
+	0: "〇",
+	1: "壱",
+	2: "弐",
+	3: "参",
+	4: "四",
+	5: "五",
+	6: "六",
+	7: "七",
+	8: "八",
+	9: "九",
+}
+
+// UnitJaSectionAN2CN 日文四位分节内的单位（从右到左）
+var UnitJaSectionAN2CN = []string{"", "十", "百", "千"}
+
+// UnitJaDaijiSectionAN2CN 日文大字四位分节内的单位（从右到左）
+var UnitJaDaijiSectionAN2CN = []string{"", "拾", "百", "千"}
+
+// UnitJaLargeAN2CN 日文万进单位（从右到左）
+var UnitJaLargeAN2CN = []string{"", "万", "億", "兆"}
+
+// UnitJaDaijiLargeAN2CN 日文大字万进单位（从右到左）
+var UnitJaDaijiLargeAN2CN = []string{"", "萬", "億", "兆"}
+
+// PinyinCN 中文数字用字的拼音（数字标调）
+var PinyinCN = map[rune]string{
+	'零': "ling2",
+	'〇': "ling2",
+	'一': "yi1",
+	'幺': "yao1",
+	'二': "er4",
+	'两': "liang3",
+	'三': "san1",
+	'四': "si4",
+	'五': "wu3",
+	'六': "liu4",
+	'七': "qi1",
+	'八': "ba1",
+	'九': "jiu3",
+	'十': "shi2",
+	'百': "bai3",
+	'千': "qian1",
+	'万': "wan4",
+	'亿': "yi4",
+	'点': "dian3",
+	'负': "fu4",
+}
+
+// PinyinMeasureWordCN 常用量词的拼音（数字标调），用于「一」的变调与「两」的选择
+var PinyinMeasureWordCN = map[rune]string{
+	'个': "ge4",
+	'次': "ci4",
+	'位': "wei4",
+	'件': "jian4",
+	'块': "kuai4",
+	'岁': "sui4",
+	'分': "fen1",
+	'秒': "miao3",
+	'天': "tian1",
+	'年': "nian2",
+	'元': "yuan2",
+	'名': "ming2",
+	'条': "tiao2",
+	'本': "ben3",
+	'种': "zhong3",
+	'张': "zhang1",
+	'只': "zhi1",
+	'杯': "bei1",
+	'家': "jia1",
+	'层': "ceng2",
+	'台': "tai2",
+	'辆': "liang4",
+	'小': "xiao3",
+}
+
+// TianganCN 天干，甲为第一
+var TianganCN = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+
+// DizhiCN 地支，子为第一
+var DizhiCN = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+
+// ZodiacCN 与地支对应的生肖
+var ZodiacCN = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+
+// ProtectedTermsCN 句子转换中不做数字转换的成语、常用词、地名和品牌名
+var ProtectedTermsCN = []string{
+	// 成语
+	"一心一意", "一五一十", "一模一样", "一清二楚", "一干二净", "一举两得", "一石二鸟", "一丝不苟",
+	"一帆风顺", "一路顺风", "一见钟情", "一目了然", "一言为定", "一无所有", "一刀两断", "一分为二",
+	"独一无二", "三心二意", "三长两短", "三番五次", "三言两语", "三五成群", "接二连三", "七上八下",
+	"乱七八糟", "七嘴八舌", "四面八方", "五湖四海", "五花八门", "五颜六色", "六神无主", "九牛一毛",
+	"九死一生", "十全十美", "十拿九稳", "千方百计", "千军万马", "千言万语", "千家万户", "千变万化",
+	"万无一失", "万众一心", "一本万利", "半斤八两", "半途而废", "一知半解", "百里挑一",
+	// 常用词
+	"统一", "万一", "一些", "一起", "一直", "一定", "一样", "一般", "一切", "唯一", "一下", "一会儿",
+	"一边", "一旦", "一致", "同一", "专一", "一向", "一再", "一律", "一度", "一同", "一味", "一概",
+	"万万", "千万别", "千万不要", "千万要", "一点儿", "一点点", "有一点", "十一假期", "十一长假", "十一黄金周",
+	// 地名
+	"四川", "三亚", "九寨沟", "五台山", "三峡", "十堰", "六安", "九江", "三明", "七台河", "万州", "万宁",
+	"二连浩特", "五指山", "九龙", "三门峡", "八达岭", "三沙", "四平", "六盘水", "九华山", "五道口", "三里屯",
+	// 品牌名
+	"三星", "一汽", "三一重工", "九阳", "五粮液", "七喜", "三只松鼠", "六神", "万达", "万科", "一加",
+	"三菱", "五菱", "七匹狼", "九牧王", "十月稻田",
+}
+
+// NumericTermsCN 与 ProtectedTermsCN 重叠但仍按数字转换的写法，如 三星期 不是 三星
+var NumericTermsCN = []string{
+	"三星期", "三星级",
+}
+
+// SegmenterWordsCN MaxMatchSegmenter 的默认词典中 ProtectedTermsCN 之外含中文数字的非数词（词语 => 词性）
+var SegmenterWordsCN = map[string]string{
+	"一流": "a", "二流": "a", "三流": "a", "一线": "n", "二线": "n", "一体": "n", "一体化": "vn", "一方面": "c",
+	"一次性": "b", "二手": "b", "一把手": "n", "三角形": "n", "十字路口": "n", "万能": "a", "百货": "n",
+	"十分": "d", "万分": "d", "百般": "d", "一如既往": "i", "一塌糊涂": "i", "一本正经": "i", "一鼓作气": "i",
+	"一气呵成": "i", "一针见血": "i", "一头雾水": "i", "一举一动": "i", "一成不变": "i", "九霄云外": "i",
+}
@@ -0,0 +1,253 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DateFields 日期中实际出现的字段，用于区分部分日期
+type DateFields uint8
+
+const (
+	DateHasYear DateFields = 1 << iota
+	DateHasMonth
+	DateHasDay
+	DateHasWeekday
+	DateHasTime
+)
+
+// ParsedDate 日期解析结果，缺失的年、月、日取自参考时间
+type ParsedDate struct {
+	Time   time.Time
+	Fields DateFields
+}
+
+// Has 判断是否包含全部指定字段
+func (d ParsedDate) Has(fields DateFields) bool {
+	return d.Fields&fields == fields
+}
+
+// DateOption 日期解析选项
+type DateOption func(*dateConfig)
+
+type dateConfig struct {
+	pivot       int
+	reference   time.Time
+	eraStart    int
+	fiscalStart time.Month
+}
+
+// WithYearPivot 设置两位年份的分界：小于 pivot 的年份视为 20xx，其余视为 19xx，默认 50
+func WithYearPivot(pivot int) DateOption {
+	return func(cfg *dateConfig) {
+		cfg.pivot = pivot
+	}
+}
+
+// WithDateReference 设置参考时间，用于补全缺失的年、月、日及时区，默认为当前时间
+func WithDateReference(reference time.Time) DateOption {
+	return func(cfg *dateConfig) {
+		cfg.reference = reference
+	}
+}
+
+// monthNameCN 月份别称
+var monthNameCN = map[string]int{
+	"元": 1,
+	"正": 1,
+	"腊": 12,
+	"臘": 12,
+}
+
+// weekdayCN 星期用字
+var weekdayCN = map[string]time.Weekday{
+	"日": time.Sunday,
+	"天": time.Sunday,
+	"一": time.Monday,
+	"二": time.Tuesday,
+	"三": time.Wednesday,
+	"四": time.Thursday,
+	"五": time.Friday,
+	"六": time.Saturday,
+	"1": time.Monday,
+	"2": time.Tuesday,
+	"3": time.Wednesday,
+	"4": time.Thursday,
+	"5": time.Friday,
+	"6": time.Saturday,
+	"7": time.Sunday,
+}
+
+const dateNumPattern = `[0-9零〇一二三四五六七八九十百千两廿卅]+`
+
+var (
+	// 二〇二四年三月五日星期二、九八年元月、3月5号、2024-03-05
+	dateCNRe      = regexp.MustCompile(fmt.Sprintf(`^(?:(%[1]s)年)?(?:(%[1]s|元|正|腊|臘)月)?(?:(%[1]s)([日号號]?))?$`, dateNumPattern))
+	dateISORe     = regexp.MustCompile(`^(\d{2,4})[-/.](\d{1,2})(?:[-/.](\d{1,2}))?$`)
+	dateWeekdayRe = regexp.MustCompile(`[\s,，]*[(（]?(?:星期|礼拜|禮拜|周|週)([日天一二三四五六1-7])[)）]?$`)
+)
+
+// ParseDate 解析中文日期，如 二〇二四年三月五日星期二、九八年元月、腊月二十三号、2024-03-05
+// 两位年份按 WithYearPivot 推断世纪；带星期时校验与日期是否一致
+func (c *Cn2An) ParseDate(inputs string, opts ...DateOption) (ParsedDate, error) {
+	cfg := dateConfig{pivot: 50, reference: time.Now()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	data := strings.TrimSpace(normalizeText(inputs))
+	if data == "" {
+		return ParsedDate{}, errors.New("输入数据为空")
+	}
+
+	var fields DateFields
+	weekday := time.Sunday
+	if loc := dateWeekdayRe.FindStringSubmatchIndex(data); loc != nil {
+		weekday = weekdayCN[data[loc[2]:loc[3]]]
+		fields |= DateHasWeekday
+		data = data[:loc[0]]
+	}
+
+	var yearStr, monthStr, dayStr string
+	if subs := dateISORe.FindStringSubmatch(data); subs != nil {
+		yearStr, monthStr, dayStr = subs[1], subs[2], subs[3]
+	} else if subs := dateCNRe.FindStringSubmatch(data); subs != nil && (subs[2] != "" || subs[4] != "") {
+		// 正月十五 可省略「日」，单独的日必须带「日」或「号」
+		yearStr, monthStr, dayStr = subs[1], subs[2], subs[3]
+	} else if subs != nil && subs[1] != "" && subs[3] == "" {
+		yearStr = subs[1]
+	} else {
+		return ParsedDate{}, fmt.Errorf("不符合格式的日期：%s", inputs)
+	}
+	if yearStr != "" && monthStr == "" && dayStr != "" {
+		return ParsedDate{}, fmt.Errorf("不符合格式的日期：%s", inputs)
+	}
+
+	year, month, day := cfg.reference.Year(), int(cfg.reference.Month()), cfg.reference.Day()
+	var err error
+	if yearStr != "" {
+		if year, err = c.dateYear(yearStr, cfg.pivot); err != nil {
+			return ParsedDate{}, err
+		}
+		fields |= DateHasYear
+	}
+	if monthStr != "" {
+		if val, ok := monthNameCN[monthStr]; ok {
+			month = val
+		} else if month, err = c.timeNumber(monthStr); err != nil {
+			return ParsedDate{}, err
+		}
+		fields |= DateHasMonth
+	} else if yearStr != "" {
+		month = 1
+	}
+	if dayStr != "" {
+		if day, err = c.timeNumber(dayStr); err != nil {
+			return ParsedDate{}, err
+		}
+		fields |= DateHasDay
+	} else if yearStr != "" || monthStr != "" {
+		day = 1
+	}
+
+	if month < 1 || month > 12 {
+		return ParsedDate{}, fmt.Errorf("月份超出范围：%s", inputs)
+	}
+	result := time.Date(year, time.Month(month), day, 0, 0, 0, 0, cfg.reference.Location())
+	if day < 1 || result.Day() != day {
+		return ParsedDate{}, fmt.Errorf("日期超出范围：%s", inputs)
+	}
+	if fields&DateHasWeekday != 0 && fields&DateHasDay != 0 && result.Weekday() != weekday {
+		return ParsedDate{}, fmt.Errorf("星期与日期不符：%s 是%s", inputs, weekdayNameCN(result.Weekday()))
+	}
+
+	return ParsedDate{Time: result, Fields: fields}, nil
+}
+
+// dateYear 解析年份：逐位读出的 二〇二四、九八，或 两千零二十四 等数值写法
+func (c *Cn2An) dateYear(s string, pivot int) (int, error) {
+	var year int
+	if strings.ContainsAny(s, "十百千万两廿卅") {
+		val, err := c.timeNumber(s)
+		if err != nil {
+			return 0, err
+		}
+		year = val
+	} else {
+		var builder strings.Builder
+		for _, r := range s {
+			if r >= '0' && r <= '9' {
+				builder.WriteRune(r)
+				continue
+			}
+			builder.WriteByte(byte('0' + NumberCN2AN[r]))
+		}
+		digits := builder.String()
+		if _, err := fmt.Sscanf(digits, "%d", &year); err != nil {
+			return 0, fmt.Errorf("不符合格式的年份：%s", s)
+		}
+		if len([]rune(s)) == 2 {
+			if year < pivot {
+				year += 2000
+			} else {
+				year += 1900
+			}
+		}
+	}
+	if year < 1 || year > 9999 {
+		return 0, fmt.Errorf("年份超出范围：%s", s)
+	}
+	return year, nil
+}
+
+// weekdayNameCN 返回星期的中文写法
+func weekdayNameCN(weekday time.Weekday) string {
+	if weekday == time.Sunday {
+		return "星期日"
+	}
+	return "星期" + NumberLowAN2CN[int(weekday)]
+}
+
+// An2cnDate 将日期转为中文，如 二零二四年三月五日星期二
+// fields 指定输出的字段，为 0 时输出年、月、日
+func (a *An2Cn) An2cnDate(t time.Time, fields DateFields) (string, error) {
+	if fields&(DateHasYear|DateHasMonth|DateHasDay|DateHasWeekday) == 0 {
+		fields = DateHasYear | DateHasMonth | DateHasDay
+	}
+	if fields&DateHasYear != 0 && fields&DateHasDay != 0 && fields&DateHasMonth == 0 {
+		return "", errors.New("缺少月份时不能同时输出年份和日")
+	}
+
+	var builder strings.Builder
+	if fields&DateHasYear != 0 {
+		if t.Year() < 1 {
+			return "", fmt.Errorf("年份超出范围：%d", t.Year())
+		}
+		year, err := a.An2cn(t.Year(), "direct")
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(year + "年")
+	}
+	if fields&DateHasMonth != 0 {
+		month, err := a.An2cn(int(t.Month()), "low")
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(month + "月")
+	}
+	if fields&DateHasDay != 0 {
+		day, err := a.An2cn(t.Day(), "low")
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(day + "日")
+	}
+	if fields&DateHasWeekday != 0 {
+		builder.WriteString(weekdayNameCN(t.Weekday()))
+	}
+	return builder.String(), nil
+}
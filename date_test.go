@@ -0,0 +1,94 @@
+package gocn2an
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	reference := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	testData := []struct {
+		input  string
+		date   string
+		fields DateFields
+	}{
+		{"二〇二四年三月五日星期二", "2024-03-05", DateHasYear | DateHasMonth | DateHasDay | DateHasWeekday},
+		{"二零二四年三月五號，礼拜二", "2024-03-05", DateHasYear | DateHasMonth | DateHasDay | DateHasWeekday},
+		{"一九九八年二月二十八日", "1998-02-28", DateHasYear | DateHasMonth | DateHasDay},
+		{"两千零八年八月八日", "2008-08-08", DateHasYear | DateHasMonth | DateHasDay},
+		{"九八年", "1998-01-01", DateHasYear},
+		{"二四年元月", "2024-01-01", DateHasYear | DateHasMonth},
+		{"正月十五", "2024-01-15", DateHasMonth | DateHasDay},
+		{"腊月二十三号", "2024-12-23", DateHasMonth | DateHasDay},
+		{"三月五日（周二）", "2024-03-05", DateHasMonth | DateHasDay | DateHasWeekday},
+		{"廿八日", "2024-06-28", DateHasDay},
+		{"2024-03-05", "2024-03-05", DateHasYear | DateHasMonth | DateHasDay},
+		{"24/3/5", "2024-03-05", DateHasYear | DateHasMonth | DateHasDay},
+	}
+
+	c := NewCn2An()
+	for _, item := range testData {
+		result, err := c.ParseDate(item.input, WithDateReference(reference))
+		if err != nil {
+			t.Errorf("ParseDate(%q) error: %v", item.input, err)
+			continue
+		}
+		if got := result.Time.Format("2006-01-02"); got != item.date {
+			t.Errorf("ParseDate(%q) = %s, want %s", item.input, got, item.date)
+		}
+		if result.Fields != item.fields {
+			t.Errorf("ParseDate(%q) fields = %b, want %b", item.input, result.Fields, item.fields)
+		}
+	}
+
+	for _, input := range []string{"", "二〇二四年三月五日星期三", "一九九八年二月三十日", "十三月", "星期二", "明天", "五", "二〇二四年五日"} {
+		if _, err := c.ParseDate(input, WithDateReference(reference)); err == nil {
+			t.Errorf("ParseDate(%q) should return error but got nil", input)
+		}
+	}
+}
+
+func TestParseDateYearPivot(t *testing.T) {
+	c := NewCn2An()
+	testData := map[int]int{50: 2030, 31: 2030, 30: 1930}
+	for pivot, expected := range testData {
+		result, err := c.ParseDate("三〇年", WithYearPivot(pivot))
+		if err != nil {
+			t.Errorf("ParseDate(%q) with pivot %d error: %v", "三〇年", pivot, err)
+			continue
+		}
+		if result.Time.Year() != expected {
+			t.Errorf("ParseDate(%q) with pivot %d = %d, want %d", "三〇年", pivot, result.Time.Year(), expected)
+		}
+	}
+}
+
+func TestAn2cnDate(t *testing.T) {
+	date := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	testData := []struct {
+		fields   DateFields
+		expected string
+	}{
+		{0, "二零二四年三月五日"},
+		{DateHasYear | DateHasMonth | DateHasDay | DateHasWeekday, "二零二四年三月五日星期二"},
+		{DateHasMonth | DateHasDay, "三月五日"},
+		{DateHasYear, "二零二四年"},
+		{DateHasWeekday, "星期二"},
+	}
+
+	a := NewAn2Cn()
+	for _, item := range testData {
+		result, err := a.An2cnDate(date, item.fields)
+		if err != nil {
+			t.Errorf("An2cnDate(%v, %b) error: %v", date, item.fields, err)
+			continue
+		}
+		if result != item.expected {
+			t.Errorf("An2cnDate(%v, %b) = %s, want %s", date, item.fields, result, item.expected)
+		}
+	}
+
+	if _, err := a.An2cnDate(date, DateHasYear|DateHasDay); err == nil {
+		t.Errorf("An2cnDate without month should return error but got nil")
+	}
+}
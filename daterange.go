@@ -0,0 +1,317 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DateRange 世纪、年代、季度、半年、财年等时间段，Start 为第一天，End 为最后一天
+type DateRange struct {
+	Kind     string // century, decade, quarter, half, fiscal_year
+	Value    int    // 世纪序数、年代起始年份、季度序号或半年序号（1 上半年，2 下半年）
+	Year     int    // 季度、半年、财年所属年份
+	Fiscal   bool   // 是否为财年中的季度或半年
+	Modifier string // 初、中、末、上半叶、下半叶
+	Start    time.Time
+	End      time.Time
+}
+
+// WithFiscalYearStart 设置财年起始月份，N 财年从 N 年该月开始，默认 1 月
+func WithFiscalYearStart(month time.Month) DateOption {
+	return func(cfg *dateConfig) {
+		cfg.fiscalStart = month
+	}
+}
+
+// dateRangeModifier 世纪、年代修饰词的规范写法
+var dateRangeModifier = map[string]string{
+	"初":   "初",
+	"初期":  "初",
+	"中":   "中",
+	"中期":  "中",
+	"中叶":  "中",
+	"末":   "末",
+	"末期":  "末",
+	"上半叶": "上半叶",
+	"前半叶": "上半叶",
+	"下半叶": "下半叶",
+	"后半叶": "下半叶",
+}
+
+const (
+	dateRangeYearPattern     = `[0-9零〇一二三四五六七八九十百千两]+`
+	dateRangeModifierPattern = `初期|初|中期|中叶|中|末期|末|上半叶|前半叶|下半叶|后半叶`
+)
+
+var (
+	// 二十一世纪、上世纪末、21世纪中叶
+	centuryRe = regexp.MustCompile(fmt.Sprintf(`^(?:([上本下这])个?世纪|(%s)世纪)(%s)?$`, dateRangeYearPattern, dateRangeModifierPattern))
+	// 八十年代、上世纪九十年代末、1980年代
+	decadeRe = regexp.MustCompile(fmt.Sprintf(`^(?:([上本下这])个?世纪|(%s)世纪)?([一二三四五六七八九]十|零零|[0-9]0|[0-9]{3}0)年代(初期|初|中期|中|末期|末)?$`, dateRangeYearPattern))
+	// 第三季度、2024年Q3、二〇二四财年第一季度、上半年
+	yearPartRe = regexp.MustCompile(fmt.Sprintf(`^(?:(今|明|去|前|后)年|(%[1]s)年|(%[1]s)?财年|FY([0-9]{2,4}))?(?:第?([一二三四1-4])季度?|[Qq]([1-4])|([上下])半年)?$`, dateRangeYearPattern))
+
+	// 句子中的季度：第三季度、三季度 => Q3
+	quarterTextRe = regexp.MustCompile(`第?[一二三四]季度`)
+	// 句子中的季度、财年和年代：Q3 => 第三季度、2024财年 => 二零二四财年、FY2024 => 二零二四财年、80年代 => 八十年代
+	dateRangeAn2cnRe = regexp.MustCompile(`\b(?:[Qq]([1-4])|FY([0-9]{2,4}))\b|([0-9]{2,4})财年|\b([1-9]0)年代`)
+)
+
+// ParseDateRange 解析世纪、年代、季度、半年、财年，如 八十年代、二十一世纪、上世纪九十年代末、
+// 第三季度、二〇二四财年上半年。未写年份或世纪时以 WithDateReference 参考时间推断，
+// 年代取不晚于参考年份的最近一个
+func (c *Cn2An) ParseDateRange(inputs string, opts ...DateOption) (DateRange, error) {
+	cfg := dateConfig{pivot: 50, reference: time.Now(), fiscalStart: time.January}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	data := strings.TrimSpace(normalizeText(inputs))
+	if data == "" {
+		return DateRange{}, errors.New("输入数据为空")
+	}
+	loc := cfg.reference.Location()
+	refCentury := (cfg.reference.Year()-1)/100 + 1
+
+	if subs := centuryRe.FindStringSubmatch(data); subs != nil {
+		century, err := c.centuryOf(subs[1], subs[2], refCentury)
+		if err != nil {
+			return DateRange{}, err
+		}
+		r := DateRange{Kind: "century", Value: century, Modifier: dateRangeModifier[subs[3]]}
+		first := (century-1)*100 + 1
+		start, end := first, first+99
+		switch r.Modifier {
+		case "初":
+			end = first + 19
+		case "中":
+			start, end = first+40, first+59
+		case "末":
+			start = first + 80
+		case "上半叶":
+			end = first + 49
+		case "下半叶":
+			start = first + 50
+		}
+		r.Start = time.Date(start, time.January, 1, 0, 0, 0, 0, loc)
+		r.End = time.Date(end, time.December, 31, 0, 0, 0, 0, loc)
+		return r, nil
+	}
+
+	if subs := decadeRe.FindStringSubmatch(data); subs != nil {
+		decade, err := c.decadeOf(subs, refCentury, cfg.reference.Year())
+		if err != nil {
+			return DateRange{}, err
+		}
+		r := DateRange{Kind: "decade", Value: decade, Modifier: dateRangeModifier[subs[4]]}
+		start, end := decade, decade+9
+		switch r.Modifier {
+		case "初":
+			end = decade + 3
+		case "中":
+			start, end = decade+4, decade+6
+		case "末":
+			start = decade + 7
+		}
+		r.Start = time.Date(start, time.January, 1, 0, 0, 0, 0, loc)
+		r.End = time.Date(end, time.December, 31, 0, 0, 0, 0, loc)
+		return r, nil
+	}
+
+	if subs := yearPartRe.FindStringSubmatch(data); subs != nil {
+		r := DateRange{Year: cfg.reference.Year()}
+		var err error
+		switch {
+		case subs[1] != "":
+			r.Year += relativeYearOffset[subs[1]+"年"]
+		case subs[2] != "":
+			if r.Year, err = c.dateYear(subs[2], cfg.pivot); err != nil {
+				return DateRange{}, err
+			}
+		case subs[3] != "":
+			if r.Year, err = c.dateYear(subs[3], cfg.pivot); err != nil {
+				return DateRange{}, err
+			}
+			r.Fiscal = true
+		case subs[4] != "":
+			if r.Year, err = c.dateYear(subs[4], cfg.pivot); err != nil {
+				return DateRange{}, err
+			}
+			r.Fiscal = true
+		case strings.Contains(data, "财年"):
+			r.Fiscal = true
+		}
+
+		startMonth := time.January
+		if r.Fiscal {
+			startMonth = cfg.fiscalStart
+		}
+		months := 12
+		offset := 0
+		switch {
+		case subs[5] != "" || subs[6] != "":
+			quarter, err := c.timeNumber(subs[5] + subs[6])
+			if err != nil {
+				return DateRange{}, err
+			}
+			r.Kind, r.Value = "quarter", quarter
+			months, offset = 3, (quarter-1)*3
+		case subs[7] != "":
+			r.Kind, r.Value = "half", 1
+			if subs[7] == "下" {
+				r.Value = 2
+			}
+			months, offset = 6, (r.Value-1)*6
+		case r.Fiscal:
+			r.Kind = "fiscal_year"
+		default:
+			return DateRange{}, fmt.Errorf("不符合格式的时间段：%s", inputs)
+		}
+
+		r.Start = time.Date(r.Year, startMonth, 1, 0, 0, 0, 0, loc).AddDate(0, offset, 0)
+		r.End = r.Start.AddDate(0, months, -1)
+		return r, nil
+	}
+
+	return DateRange{}, fmt.Errorf("不符合格式的时间段：%s", inputs)
+}
+
+// centuryOf 解析世纪序数：上世纪、本世纪、二十一世纪、21世纪
+func (c *Cn2An) centuryOf(relative, number string, refCentury int) (int, error) {
+	switch relative {
+	case "上":
+		return refCentury - 1, nil
+	case "本", "这":
+		return refCentury, nil
+	case "下":
+		return refCentury + 1, nil
+	}
+	century, err := c.timeNumber(number)
+	if err != nil {
+		return 0, err
+	}
+	if century < 1 {
+		return 0, fmt.Errorf("世纪超出范围：%s", number)
+	}
+	return century, nil
+}
+
+// decadeOf 解析年代的起始年份
+func (c *Cn2An) decadeOf(subs []string, refCentury, refYear int) (int, error) {
+	text := subs[3]
+	if len(text) == 4 && text[0] >= '0' && text[0] <= '9' {
+		// 1980年代
+		var year int
+		if _, err := fmt.Sscanf(text, "%d", &year); err != nil {
+			return 0, err
+		}
+		return year, nil
+	}
+
+	tens := 0
+	if text != "零零" {
+		val, err := c.timeNumber(text)
+		if err != nil {
+			return 0, err
+		}
+		tens = val
+	}
+
+	if subs[1] != "" || subs[2] != "" {
+		century, err := c.centuryOf(subs[1], subs[2], refCentury)
+		if err != nil {
+			return 0, err
+		}
+		// 二十世纪九十年代 => 1990，二十一世纪零零年代 => 2000
+		return (century-1)*100 + tens, nil
+	}
+
+	// 取不晚于参考年份的最近一个年代
+	decade := refYear/100*100 + tens
+	if decade > refYear {
+		decade -= 100
+	}
+	return decade, nil
+}
+
+// An2cnDateRange 将时间段转为中文，如 二十世纪八十年代末、二十一世纪、二零二四年第三季度、二零二四财年上半年
+func (a *An2Cn) An2cnDateRange(r DateRange) (string, error) {
+	var builder strings.Builder
+	writeYear := func() error {
+		year, err := a.An2cn(r.Year, "direct")
+		if err != nil {
+			return err
+		}
+		builder.WriteString(year)
+		if r.Fiscal {
+			builder.WriteString("财年")
+		} else {
+			builder.WriteString("年")
+		}
+		return nil
+	}
+
+	switch r.Kind {
+	case "century":
+		century, err := a.An2cn(r.Value, "low")
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(century + "世纪" + centuryModifierCN(r.Modifier))
+	case "decade":
+		century, err := a.An2cn(r.Value/100+1, "low")
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(century + "世纪")
+		if r.Value%100 == 0 {
+			builder.WriteString("零零")
+		} else {
+			tens, err := a.An2cn(r.Value%100, "low")
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(tens)
+		}
+		builder.WriteString("年代" + r.Modifier)
+	case "quarter":
+		if err := writeYear(); err != nil {
+			return "", err
+		}
+		if r.Value < 1 || r.Value > 4 {
+			return "", fmt.Errorf("季度超出范围：%d", r.Value)
+		}
+		builder.WriteString("第" + NumberLowAN2CN[r.Value] + "季度")
+	case "half":
+		if err := writeYear(); err != nil {
+			return "", err
+		}
+		switch r.Value {
+		case 1:
+			builder.WriteString("上半年")
+		case 2:
+			builder.WriteString("下半年")
+		default:
+			return "", fmt.Errorf("半年序号超出范围：%d", r.Value)
+		}
+	case "fiscal_year":
+		r.Fiscal = true
+		if err := writeYear(); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("不支持的时间段类型：%s", r.Kind)
+	}
+	return builder.String(), nil
+}
+
+// centuryModifierCN 世纪的修饰词写法，中 写作 中叶
+func centuryModifierCN(modifier string) string {
+	if modifier == "中" {
+		return "中叶"
+	}
+	return modifier
+}
@@ -0,0 +1,85 @@
+package gocn2an
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	reference := WithDateReference(time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC))
+	testData := map[string][2]string{
+		"八十年代":      {"1980-01-01", "1989-12-31"},
+		"二十年代":      {"2020-01-01", "2029-12-31"},
+		"1980年代":    {"1980-01-01", "1989-12-31"},
+		"二十一世纪":     {"2001-01-01", "2100-12-31"},
+		"上世纪":       {"1901-01-01", "2000-12-31"},
+		"二十世纪初":     {"1901-01-01", "1920-12-31"},
+		"本世纪中叶":     {"2041-01-01", "2060-12-31"},
+		"上世纪九十年代末":  {"1997-01-01", "1999-12-31"},
+		"二十一世纪零零年代": {"2000-01-01", "2009-12-31"},
+		"第三季度":      {"2024-07-01", "2024-09-30"},
+		"二〇二四年第一季度": {"2024-01-01", "2024-03-31"},
+		"2024年Q3":   {"2024-07-01", "2024-09-30"},
+		"明年第二季度":    {"2025-04-01", "2025-06-30"},
+		"下半年":       {"2024-07-01", "2024-12-31"},
+		"二〇二四财年上半年": {"2024-01-01", "2024-06-30"},
+		"FY2025":    {"2025-01-01", "2025-12-31"},
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.ParseDateRange(input, reference)
+		if err != nil {
+			t.Errorf("ParseDateRange(%q) error: %v", input, err)
+			continue
+		}
+		start, end := result.Start.Format("2006-01-02"), result.End.Format("2006-01-02")
+		if start != expected[0] || end != expected[1] {
+			t.Errorf("ParseDateRange(%q) = %s ~ %s, want %s ~ %s", input, start, end, expected[0], expected[1])
+		}
+	}
+
+	// 财年从 4 月开始
+	result, err := c.ParseDateRange("二〇二四财年第一季度", reference, WithFiscalYearStart(time.April))
+	if err != nil {
+		t.Fatalf("ParseDateRange with fiscal start error: %v", err)
+	}
+	if got := result.Start.Format("2006-01-02") + " ~ " + result.End.Format("2006-01-02"); got != "2024-04-01 ~ 2024-06-30" {
+		t.Errorf("ParseDateRange with fiscal start = %s, want 2024-04-01 ~ 2024-06-30", got)
+	}
+
+	for _, input := range []string{"", "第三", "第五季度", "二〇二四年", "八十五年代"} {
+		if _, err := c.ParseDateRange(input, reference); err == nil {
+			t.Errorf("ParseDateRange(%q) should return error but got nil", input)
+		}
+	}
+}
+
+func TestAn2cnDateRange(t *testing.T) {
+	testData := map[string]DateRange{
+		"二十世纪八十年代末": {Kind: "decade", Value: 1980, Modifier: "末"},
+		"二十一世纪":     {Kind: "century", Value: 21},
+		"二十一世纪中叶":   {Kind: "century", Value: 21, Modifier: "中"},
+		"二零二四年第三季度": {Kind: "quarter", Value: 3, Year: 2024},
+		"二零二四财年上半年": {Kind: "half", Value: 1, Year: 2024, Fiscal: true},
+		"二零二五财年":    {Kind: "fiscal_year", Year: 2025},
+	}
+
+	a := NewAn2Cn()
+	for expected, input := range testData {
+		result, err := a.An2cnDateRange(input)
+		if err != nil {
+			t.Errorf("An2cnDateRange(%+v) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("An2cnDateRange(%+v) = %s, want %s", input, result, expected)
+		}
+	}
+
+	for _, input := range []DateRange{{Kind: "quarter", Value: 5, Year: 2024}, {Kind: "week"}} {
+		if _, err := a.An2cnDateRange(input); err == nil {
+			t.Errorf("An2cnDateRange(%+v) should return error but got nil", input)
+		}
+	}
+}
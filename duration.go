@@ -0,0 +1,248 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period 日历时长：年、月长度不固定，与 time.Duration 分开保存
+type Period struct {
+	Years    int
+	Months   int
+	Duration time.Duration
+}
+
+// AddTo 返回 t 加上该时长后的时间，年、月按日历计算
+func (p Period) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.Years, p.Months, 0).Add(p.Duration)
+}
+
+// IsZero 判断时长是否为零
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Duration == 0
+}
+
+// durationModeList An2cnDuration 支持的模式：compact(1小时30分), full(一小时三十分钟)
+var durationModeList = []string{"compact", "full"}
+
+// durationUnit 时长单位
+type durationUnit struct {
+	months   int // 以月计的单位（年、月）
+	duration time.Duration
+}
+
+// durationUnitMap 时长单位与长度，夜、晚按一天计
+var durationUnitMap = map[string]durationUnit{
+	"年":  {months: 12},
+	"个月": {months: 1},
+	"月":  {months: 1},
+	"星期": {duration: 7 * 24 * time.Hour},
+	"礼拜": {duration: 7 * 24 * time.Hour},
+	"周":  {duration: 7 * 24 * time.Hour},
+	"天":  {duration: 24 * time.Hour},
+	"日":  {duration: 24 * time.Hour},
+	"夜":  {duration: 24 * time.Hour},
+	"晚":  {duration: 24 * time.Hour},
+	"小时": {duration: time.Hour},
+	"钟头": {duration: time.Hour},
+	"钟":  {duration: time.Hour},
+	"时":  {duration: time.Hour},
+	"刻钟": {duration: 15 * time.Minute},
+	"刻":  {duration: 15 * time.Minute},
+	"分钟": {duration: time.Minute},
+	"分":  {duration: time.Minute},
+	"秒钟": {duration: time.Second},
+	"秒":  {duration: time.Second},
+	"毫秒": {duration: time.Millisecond},
+	"微秒": {duration: time.Microsecond},
+	"纳秒": {duration: time.Nanosecond},
+}
+
+const (
+	durationNumPattern  = `[0-9.零〇一二三四五六七八九十百千万两兩廿卅]+`
+	durationUnitPattern = `年|个月|個月|月|星期|礼拜|禮拜|周|週|天|日|夜|晚|小时|小時|钟头|鐘頭|钟|鐘|时|時|刻钟|刻鐘|刻|分钟|分鐘|分|秒钟|秒鐘|毫秒|微秒|纳秒|納秒|秒`
+	// 句子转换只识别不易与日期、分数、时刻混淆的单位
+	durationTextUnitPattern = `个月|個月|星期|礼拜|禮拜|周|週|天|夜|小时|小時|钟头|鐘頭|分钟|分鐘|秒钟|秒鐘|毫秒|秒`
+)
+
+var (
+	// 一个半小时、半小时、三天半、零三个月
+	durationPartRe = regexp.MustCompile(fmt.Sprintf(`^[又零〇]?(?:(%s)[个個]?(半)?|(半)[个個]?)(%s)(半)?`, durationNumPattern, durationUnitPattern))
+	durationTextRe = regexp.MustCompile(fmt.Sprintf(`%[1]s[个個]半月|(?:%[1]s[个個]?半?|半[个個]?)(?:%[2]s)半?`, durationNumPattern, durationTextUnitPattern))
+)
+
+// ParsePeriod 解析中文时长，如 一年零三个月、三天两夜、一个半小时、两分三十秒、1小时30分
+// 「三天两夜」中的夜与天重叠，只计天数
+func (c *Cn2An) ParsePeriod(inputs string) (Period, error) {
+	data := strings.TrimSpace(normalizeText(inputs))
+	if data == "" {
+		return Period{}, errors.New("输入数据为空")
+	}
+
+	negative := false
+	if strings.HasPrefix(data, "负") || strings.HasPrefix(data, "-") {
+		negative = true
+		data = strings.TrimLeft(data, "负-")
+	}
+
+	var months float64
+	var duration float64
+	var days, nights float64
+	for data != "" {
+		subs := durationPartRe.FindStringSubmatch(data)
+		if subs == nil {
+			return Period{}, fmt.Errorf("不符合格式的时长：%s", inputs)
+		}
+		data = data[len(subs[0]):]
+
+		val := 0.0
+		if subs[1] != "" {
+			num, err := c.Cn2an(subs[1], "smart")
+			if err != nil {
+				return Period{}, err
+			}
+			val = num
+		}
+		if subs[2] != "" || subs[3] != "" || subs[5] != "" {
+			if subs[2] != "" && subs[5] != "" || subs[3] != "" && subs[5] != "" {
+				return Period{}, fmt.Errorf("不符合格式的时长：%s", inputs)
+			}
+			val += 0.5
+		}
+
+		unitName := strings.NewReplacer("個", "个", "禮", "礼", "週", "周", "時", "时", "鐘", "钟", "頭", "头", "納", "纳").Replace(subs[4])
+		unit := durationUnitMap[unitName]
+		switch {
+		case unitName == "夜" || unitName == "晚":
+			nights += val
+		case unitName == "天" || unitName == "日":
+			days += val
+		case unit.months > 0:
+			months += val * float64(unit.months)
+		default:
+			duration += val * float64(unit.duration)
+		}
+	}
+
+	// 三天两夜：夜数不超过天数时视为同一段时间
+	if days > 0 && nights <= days {
+		nights = 0
+	}
+	duration += (days + nights) * float64(24*time.Hour)
+
+	// 不足一个月的部分按三十天计
+	wholeMonths := math.Floor(months)
+	duration += (months - wholeMonths) * 30 * float64(24*time.Hour)
+
+	p := Period{
+		Years:    int(wholeMonths) / 12,
+		Months:   int(wholeMonths) % 12,
+		Duration: time.Duration(math.Round(duration)),
+	}
+	if negative {
+		p = Period{Years: -p.Years, Months: -p.Months, Duration: -p.Duration}
+	}
+	return p, nil
+}
+
+// ParseDuration 解析不含年、月的中文时长，如 一个半小时 => 1h30m
+func (c *Cn2An) ParseDuration(inputs string) (time.Duration, error) {
+	p, err := c.ParsePeriod(inputs)
+	if err != nil {
+		return 0, err
+	}
+	if p.Years != 0 || p.Months != 0 {
+		return 0, fmt.Errorf("时长包含年、月，请使用 ParsePeriod：%s", inputs)
+	}
+	return p.Duration, nil
+}
+
+// An2cnDuration 将 time.Duration 转为中文时长
+// mode: compact(1小时30分), full(一小时三十分钟)
+func (a *An2Cn) An2cnDuration(d time.Duration, mode string) (string, error) {
+	return a.An2cnPeriod(Period{Duration: d}, mode)
+}
+
+// An2cnPeriod 将日历时长转为中文
+// mode: compact(1年3个月), full(一年零三个月)
+func (a *An2Cn) An2cnPeriod(p Period, mode string) (string, error) {
+	if !contains(durationModeList, mode) {
+		return "", fmt.Errorf("mode 仅支持 %v", durationModeList)
+	}
+
+	negative := p.Years < 0 || p.Months < 0 || p.Duration < 0
+	if negative && (p.Years > 0 || p.Months > 0 || p.Duration > 0) {
+		return "", fmt.Errorf("时长各部分的符号不一致：%+v", p)
+	}
+	if negative {
+		p = Period{Years: -p.Years, Months: -p.Months, Duration: -p.Duration}
+	}
+
+	days := p.Duration / (24 * time.Hour)
+	rest := p.Duration % (24 * time.Hour)
+	hours := rest / time.Hour
+	rest %= time.Hour
+	minutes := rest / time.Minute
+	seconds := (rest % time.Minute).Seconds()
+
+	parts := []struct {
+		val          float64
+		compact      string
+		full         string
+		zeroAfterPre bool // 全中文时与前一项之间加「零」，如 一年零三个月
+	}{
+		{float64(p.Years), "年", "年", false},
+		{float64(p.Months), "个月", "个月", true},
+		{float64(days), "天", "天", false},
+		{float64(hours), "小时", "小时", false},
+		{float64(minutes), "分", "分钟", false},
+		{seconds, "秒", "秒", false},
+	}
+
+	var builder strings.Builder
+	if negative {
+		if mode == "full" {
+			builder.WriteString("负")
+		} else {
+			builder.WriteString("-")
+		}
+	}
+
+	written, skipped := false, false
+	for _, part := range parts {
+		if part.val == 0 {
+			skipped = skipped || written
+			continue
+		}
+		numStr := strconv.FormatFloat(part.val, 'f', -1, 64)
+		if mode == "compact" {
+			builder.WriteString(numStr + part.compact)
+		} else {
+			if written && (skipped || part.zeroAfterPre) {
+				builder.WriteString("零")
+			}
+			cn, err := a.An2cn(numStr, "low")
+			if err != nil {
+				return "", err
+			}
+			if cn == "二" {
+				cn = "两"
+			}
+			builder.WriteString(cn + part.full)
+		}
+		written, skipped = true, false
+	}
+
+	if !written {
+		if mode == "compact" {
+			return "0秒", nil
+		}
+		return "零秒", nil
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,117 @@
+package gocn2an
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	testData := map[string]Period{
+		"一个半小时":  {Duration: 90 * time.Minute},
+		"半小时":    {Duration: 30 * time.Minute},
+		"三天两夜":   {Duration: 72 * time.Hour},
+		"两夜":     {Duration: 48 * time.Hour},
+		"三天半":    {Duration: 84 * time.Hour},
+		"两分三十秒":  {Duration: 2*time.Minute + 30*time.Second},
+		"一刻钟":    {Duration: 15 * time.Minute},
+		"三个钟头":   {Duration: 3 * time.Hour},
+		"两个星期":   {Duration: 14 * 24 * time.Hour},
+		"1小时30分": {Duration: 90 * time.Minute},
+		"一年零三个月": {Years: 1, Months: 3},
+		"一年半":    {Years: 1, Months: 6},
+		"两个半月":   {Months: 2, Duration: 15 * 24 * time.Hour},
+		"一年又两天":  {Years: 1, Duration: 48 * time.Hour},
+		"负两小时":   {Duration: -2 * time.Hour},
+		"三百毫秒":   {Duration: 300 * time.Millisecond},
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.ParsePeriod(input)
+		if err != nil {
+			t.Errorf("ParsePeriod(%q) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("ParsePeriod(%q) = %+v, want %+v", input, result, expected)
+		}
+	}
+
+	for _, input := range []string{"", "三点", "一个半小时半", "小时"} {
+		if _, err := c.ParsePeriod(input); err == nil {
+			t.Errorf("ParsePeriod(%q) should return error but got nil", input)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	c := NewCn2An()
+	result, err := c.ParseDuration("一个半小时")
+	if err != nil {
+		t.Fatalf("ParseDuration(%q) error: %v", "一个半小时", err)
+	}
+	if result != 90*time.Minute {
+		t.Errorf("ParseDuration(%q) = %v, want %v", "一个半小时", result, 90*time.Minute)
+	}
+	if _, err := c.ParseDuration("一年零三个月"); err == nil {
+		t.Errorf("ParseDuration(%q) should return error but got nil", "一年零三个月")
+	}
+}
+
+func TestPeriodAddTo(t *testing.T) {
+	start := time.Date(2024, 1, 31, 8, 0, 0, 0, time.UTC)
+	result := Period{Years: 1, Months: 1, Duration: 90 * time.Minute}.AddTo(start)
+	expected := time.Date(2025, 3, 3, 9, 30, 0, 0, time.UTC)
+	if !result.Equal(expected) {
+		t.Errorf("AddTo(%v) = %v, want %v", start, result, expected)
+	}
+}
+
+func TestAn2cnDuration(t *testing.T) {
+	testData := []struct {
+		input    time.Duration
+		compact  string
+		expected string
+	}{
+		{90 * time.Minute, "1小时30分", "一小时三十分钟"},
+		{2*time.Minute + 30*time.Second, "2分30秒", "两分钟三十秒"},
+		{26*time.Hour + 5*time.Second, "1天2小时5秒", "一天两小时零五秒"},
+		{1500 * time.Millisecond, "1.5秒", "一点五秒"},
+		{0, "0秒", "零秒"},
+		{-2 * time.Hour, "-2小时", "负两小时"},
+	}
+
+	a := NewAn2Cn()
+	for _, item := range testData {
+		result, err := a.An2cnDuration(item.input, "compact")
+		if err != nil {
+			t.Errorf("An2cnDuration(%v, compact) error: %v", item.input, err)
+		} else if result != item.compact {
+			t.Errorf("An2cnDuration(%v, compact) = %s, want %s", item.input, result, item.compact)
+		}
+		result, err = a.An2cnDuration(item.input, "full")
+		if err != nil {
+			t.Errorf("An2cnDuration(%v, full) error: %v", item.input, err)
+		} else if result != item.expected {
+			t.Errorf("An2cnDuration(%v, full) = %s, want %s", item.input, result, item.expected)
+		}
+	}
+
+	if _, err := a.An2cnDuration(time.Hour, "short"); err == nil {
+		t.Errorf("An2cnDuration with mode %q should return error but got nil", "short")
+	}
+}
+
+func TestAn2cnPeriod(t *testing.T) {
+	a := NewAn2Cn()
+	p := Period{Years: 1, Months: 3}
+	if result, _ := a.An2cnPeriod(p, "full"); result != "一年零三个月" {
+		t.Errorf("An2cnPeriod(%+v, full) = %s, want %s", p, result, "一年零三个月")
+	}
+	if result, _ := a.An2cnPeriod(p, "compact"); result != "1年3个月" {
+		t.Errorf("An2cnPeriod(%+v, compact) = %s, want %s", p, result, "1年3个月")
+	}
+	if _, err := a.An2cnPeriod(Period{Years: 1, Duration: -time.Hour}, "full"); err == nil {
+		t.Errorf("An2cnPeriod with mixed signs should return error but got nil")
+	}
+}
@@ -0,0 +1,79 @@
+package gocn2an
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConversionError 句子转换中识别出但未能完整转换的数字片段，该片段在输出中保持原样或只部分转换
+type ConversionError struct {
+	// Start、End 为片段在输入中的字节偏移
+	Start int
+	End   int
+	Text  string
+	// Category 类别，同 Entity.Category
+	Category string
+	Err      error
+}
+
+func (e ConversionError) Error() string {
+	return fmt.Sprintf("%s「%s」(%d-%d) 未能转换：%v", e.Category, e.Text, e.Start, e.End, e.Err)
+}
+
+func (e ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// ConversionErrors 严格模式下 Transform 随输出一起返回的全部转换失败
+type ConversionErrors []ConversionError
+
+func (e ConversionErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "；")
+}
+
+func (e ConversionErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// WithStrictErrors 严格模式：存在未能转换的数字时，Transform 在返回输出的同时返回 ConversionErrors
+func WithStrictErrors() TransformOption {
+	return func(t *Transform) {
+		t.strict = true
+	}
+}
+
+// WithWarningHandler 非严格模式下，每个未能转换的数字片段都会调用 handler，Transform 仍不返回错误
+func WithWarningHandler(handler func(ConversionError)) TransformOption {
+	return func(t *Transform) {
+		t.warningHandler = handler
+	}
+}
+
+// reportsFailures 是否需要收集转换失败
+func (t *Transform) reportsFailures() bool {
+	return t.strict || t.warningHandler != nil
+}
+
+// reportFailures 严格模式下返回转换失败，否则交给 warning handler
+func (t *Transform) reportFailures(failures []ConversionError) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	if t.strict {
+		return ConversionErrors(failures)
+	}
+	if t.warningHandler != nil {
+		for _, failure := range failures {
+			t.warningHandler(failure)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package gocn2an
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTransformStrictErrors(t *testing.T) {
+	testData := []struct {
+		input, method, expected string
+		failures                []string
+	}{
+		{"ＡＢ万岁，共一百个", "cn2an", "ＡＢ万岁，共100个", nil},
+		{"ＡＢ一二三四五六七八九十，共一百个", "cn2an", "ＡＢ一二三四五六七八九十，共100个", []string{"cardinal 6-36 一二三四五六七八九十"}},
+		{"共12345678901234567890%和3个", "an2cn", "共12345678901234567890%和三个", []string{"percent 3-24 12345678901234567890%"}},
+		{"3-2=1，99999999999999999999", "an2cn", "三减二等于一，99999999999999999999", []string{"cardinal 8-28 99999999999999999999"}},
+		// 只有部分数字未能转换时输出与默认模式相同
+		{"二百分之百", "cn2an", "百/200", []string{"fraction 0-15 二百分之百"}},
+		{"1/99999999999999999999", "an2cn", "99999999999999999999分之一", []string{"fraction 0-22 1/99999999999999999999"}},
+	}
+
+	transform := NewTransform(WithStrictErrors())
+	for _, item := range testData {
+		output, err := transform.Transform(item.input, item.method)
+		if output != item.expected {
+			t.Errorf("Transform(%q, %s) = %q, want %q", item.input, item.method, output, item.expected)
+		}
+		var got []string
+		var failures ConversionErrors
+		if errors.As(err, &failures) {
+			for _, failure := range failures {
+				if failure.Err == nil {
+					t.Errorf("Transform(%q, %s) failure %v has no underlying error", item.input, item.method, failure)
+				}
+				got = append(got, failure.Category+" "+strconv.Itoa(failure.Start)+"-"+strconv.Itoa(failure.End)+" "+failure.Text)
+			}
+		} else if err != nil {
+			t.Errorf("Transform(%q, %s) error = %v, want ConversionErrors", item.input, item.method, err)
+		}
+		if strings.Join(got, "|") != strings.Join(item.failures, "|") {
+			t.Errorf("Transform(%q, %s) failures = %v, want %v", item.input, item.method, got, item.failures)
+		}
+	}
+
+	// 默认不返回错误
+	if output, err := NewTransform().Transform("共99999999999999999999个", "an2cn"); err != nil || output != "共99999999999999999999个" {
+		t.Errorf("Transform = %q, %v", output, err)
+	}
+
+	// 长文本中的失败位置，记录开销随长度线性增长
+	prefix := strings.Repeat("x+y≤z，", 5000)
+	_, err := transform.Transform(prefix+"99999999999999999999", "an2cn")
+	if failures, ok := err.(ConversionErrors); !ok || len(failures) != 1 || failures[0].Start != len(prefix) {
+		t.Errorf("Transform(long) error = %v, want one failure at %d", err, len(prefix))
+	}
+
+	// 流式转换先输出已转换的内容，再返回错误
+	stream, _ := newStreamTransformer(transform, "cn2an", 16, 16)
+	output, err := io.ReadAll(&transformReader{r: strings.NewReader("共一百个\n一二三四五六七八九十\n共两个\n"), stream: stream})
+	var failures ConversionErrors
+	if !errors.As(err, &failures) || !strings.HasPrefix(string(output), "共100个\n一二三四五六七八九十\n") {
+		t.Errorf("NewReader = %q, %v, want output before ConversionErrors", output, err)
+	}
+	var buf strings.Builder
+	writer := transform.NewWriter(&buf, "cn2an")
+	writer.Write([]byte("共一百个\n一二三四五六七八九十\n"))
+	if err := writer.Close(); !errors.As(err, &failures) || buf.String() != "共100个\n一二三四五六七八九十\n" {
+		t.Errorf("NewWriter = %q, %v, want output before ConversionErrors", buf.String(), err)
+	}
+}
+
+func TestTransformWarningHandler(t *testing.T) {
+	var warnings []ConversionError
+	transform := NewTransform(WithWarningHandler(func(e ConversionError) {
+		warnings = append(warnings, e)
+	}))
+
+	output, err := transform.Transform("一二三四五六七八九十", "cn2an")
+	if err != nil || output != "一二三四五六七八九十" || len(warnings) != 1 || warnings[0].Start != 0 || warnings[0].End != 30 {
+		t.Errorf("Transform = %q, %v, warnings %v", output, err, warnings)
+	}
+
+	// 标记文本中的位置相对于整个输入
+	warnings = nil
+	if _, err := transform.TransformMarkdown("# 标题\n\n共99999999999999999999个", "an2cn"); err != nil || len(warnings) != 1 || warnings[0].Start != 13 {
+		t.Errorf("TransformMarkdown error %v, warnings %v", err, warnings)
+	}
+
+	// 流式转换的位置相对于整个输入，且不重复报告
+	warnings = nil
+	line := "共99999999999999999999个\n"
+	input := strings.Repeat(line, 100)
+	stream, _ := newStreamTransformer(transform, "an2cn", 64, 32)
+	if _, err := io.ReadAll(&transformReader{r: strings.NewReader(input), stream: stream}); err != nil {
+		t.Fatalf("NewReader error: %v", err)
+	}
+	if len(warnings) != 100 {
+		t.Fatalf("NewReader warnings = %d, want 100", len(warnings))
+	}
+	for i, warning := range warnings {
+		if start := i*len(line) + len("共"); warning.Start != start || input[warning.Start:warning.End] != warning.Text {
+			t.Errorf("NewReader warning %d = %v, want start %d", i, warning, start)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package gocn2an
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldRule 结构化数据中一个字段的转换规则
+type FieldRule struct {
+	// Selector JSON 中为 JSON Pointer（如 /items/*/amount_cn，* 匹配任意数组下标或键）；
+	// CSV 中为列名（有表头时）或从 0 开始的列序号
+	Selector string
+	// Method 转换方法：cn2an（结果为数值）、an2cn、transform
+	Method string
+	// Mode cn2an 为 strict、normal、smart 等，an2cn 为 low、up、rmb 等，transform 为 cn2an 或 an2cn
+	Mode string
+}
+
+// FieldError 单个字段的转换错误
+type FieldError struct {
+	// Path JSON 中为字段的 JSON Pointer，CSV 中为 行号/列名，行号从 1 开始并包含表头
+	Path string
+	Rule FieldRule
+	Err  error
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// TransformJSON 按规则转换 encoding/json 解码得到的值（map[string]any、[]any、string、float64、json.Number 等），
+// 对象和数组原地修改并返回转换后的根值；转换失败的字段保持原值，错误按规则和位置顺序收集，不会中止
+func (t *Transform) TransformJSON(v any, rules []FieldRule) (any, []FieldError) {
+	var errs []FieldError
+	for _, rule := range rules {
+		tokens, err := parseJSONPointer(rule.Selector)
+		if err != nil {
+			errs = append(errs, FieldError{Path: rule.Selector, Rule: rule, Err: err})
+			continue
+		}
+		v = t.walkJSON(v, tokens, "", rule, &errs)
+	}
+	return v, errs
+}
+
+// parseJSONPointer 解析 JSON Pointer，空串表示根
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("不符合格式的 JSON Pointer：%s", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// walkJSON 沿 tokens 找到字段并转换，返回替换后的值；* 以外的 token 找不到对应的键或下标时记录错误
+func (t *Transform) walkJSON(v any, tokens []string, path string, rule FieldRule, errs *[]FieldError) any {
+	if len(tokens) == 0 {
+		output, err := t.applyFieldRule(v, rule)
+		if err != nil {
+			*errs = append(*errs, FieldError{Path: path, Rule: rule, Err: err})
+			return v
+		}
+		return output
+	}
+
+	token, rest := tokens[0], tokens[1:]
+	missing := func() {
+		escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+		*errs = append(*errs, FieldError{Path: path + "/" + escaped, Rule: rule, Err: fmt.Errorf("不存在的字段：%s", rule.Selector)})
+	}
+	switch node := v.(type) {
+	case map[string]any:
+		keys := []string{token}
+		if token == "*" {
+			keys = make([]string, 0, len(node))
+			for key := range node {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+		}
+		for _, key := range keys {
+			child, ok := node[key]
+			if !ok {
+				missing()
+				continue
+			}
+			escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+			node[key] = t.walkJSON(child, rest, path+"/"+escaped, rule, errs)
+		}
+	case []any:
+		if token == "*" {
+			for i, child := range node {
+				node[i] = t.walkJSON(child, rest, path+"/"+strconv.Itoa(i), rule, errs)
+			}
+		} else if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(node) {
+			node[i] = t.walkJSON(node[i], rest, path+"/"+token, rule, errs)
+		} else {
+			missing()
+		}
+	default:
+		if token != "*" {
+			missing()
+		}
+	}
+	return v
+}
+
+// applyFieldRule 转换单个字段的值：cn2an 返回 float64，an2cn、transform 返回 string，空串保持原样
+func (t *Transform) applyFieldRule(v any, rule FieldRule) (any, error) {
+	var text string
+	switch value := v.(type) {
+	case string:
+		if value == "" {
+			return v, nil
+		}
+		text = value
+	case float64:
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	case json.Number:
+		text = string(value)
+	default:
+		return nil, fmt.Errorf("不支持的字段类型：%T", v)
+	}
+
+	switch rule.Method {
+	case "cn2an":
+		return t.cn2an.Cn2an(text, rule.Mode)
+	case "an2cn":
+		return t.an2cn.An2cn(text, rule.Mode)
+	case "transform":
+		return t.Transform(text, rule.Mode)
+	}
+	return nil, fmt.Errorf("error method: %s, only support 'cn2an', 'an2cn' and 'transform'", rule.Method)
+}
+
+// TransformCSV 逐行读取 r，按规则转换选中的列后写入 w；header 为 true 时第一行为表头，原样写出并可按列名选择。
+// 转换失败的单元格保持原值，错误收集后返回，不会中止；读写 CSV 本身出错时返回 error
+func (t *Transform) TransformCSV(r *csv.Reader, w *csv.Writer, header bool, rules []FieldRule) ([]FieldError, error) {
+	var errs []FieldError
+	var names []string
+	columns := make([]int, len(rules))
+	for i, rule := range rules {
+		columns[i] = -1
+		if n, err := strconv.Atoi(rule.Selector); err == nil && n >= 0 {
+			columns[i] = n
+		}
+	}
+	checkColumns := func() {
+		for i, rule := range rules {
+			if columns[i] == -1 {
+				errs = append(errs, FieldError{Path: "1/" + rule.Selector, Rule: rule, Err: fmt.Errorf("不存在的列：%s", rule.Selector)})
+			}
+		}
+	}
+	if !header {
+		checkColumns()
+	}
+
+	for row := 1; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errs, err
+		}
+
+		if header && row == 1 {
+			names = append([]string(nil), record...)
+			for i, rule := range rules {
+				for j, name := range names {
+					if name == rule.Selector {
+						columns[i] = j
+						break
+					}
+				}
+			}
+			checkColumns()
+		} else {
+			for i, rule := range rules {
+				col := columns[i]
+				if col < 0 || col >= len(record) {
+					continue
+				}
+				output, err := t.applyFieldRule(record[col], rule)
+				if err != nil {
+					name := strconv.Itoa(col)
+					if col < len(names) {
+						name = names[col]
+					}
+					errs = append(errs, FieldError{Path: strconv.Itoa(row) + "/" + name, Rule: rule, Err: err})
+					continue
+				}
+				if f, ok := output.(float64); ok {
+					record[col] = strconv.FormatFloat(f, 'f', -1, 64)
+				} else {
+					record[col] = output.(string)
+				}
+			}
+		}
+
+		if err := w.Write(record); err != nil {
+			return errs, err
+		}
+	}
+	w.Flush()
+	return errs, w.Error()
+}
@@ -0,0 +1,88 @@
+package gocn2an
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTransformJSON(t *testing.T) {
+	input := `{"items":[{"amount_cn":"一百二十三","price":58,"note":"共三件"},{"amount_cn":"abc","price":"12.5","note":""}],"total":"一千"}`
+	var v any
+	if err := json.Unmarshal([]byte(input), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	transform := NewTransform()
+	v, errs := transform.TransformJSON(v, []FieldRule{
+		{Selector: "/items/*/amount_cn", Method: "cn2an", Mode: "normal"},
+		{Selector: "/items/*/price", Method: "an2cn", Mode: "up"},
+		{Selector: "/items/*/note", Method: "transform", Mode: "cn2an"},
+		{Selector: "/total", Method: "cn2an", Mode: "strict"},
+		{Selector: "/missing/0", Method: "cn2an", Mode: "normal"},
+		{Selector: "/items/5/note", Method: "cn2an", Mode: "normal"},
+		{Selector: "/total/0", Method: "cn2an", Mode: "normal"},
+		{Selector: "/items/0/note", Method: "unknown"},
+		{Selector: "items", Method: "cn2an", Mode: "normal"},
+	})
+	output, _ := json.Marshal(v)
+	expected := `{"items":[{"amount_cn":123,"note":"共3件","price":"伍拾捌"},{"amount_cn":"abc","note":"","price":"壹拾贰点伍"}],"total":1000}`
+	if string(output) != expected {
+		t.Errorf("TransformJSON = %s, want %s", output, expected)
+	}
+
+	var paths []string
+	for _, err := range errs {
+		paths = append(paths, err.Path)
+	}
+	if want := []string{"/items/1/amount_cn", "/missing", "/items/5", "/total/0", "/items/0/note", "items"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("TransformJSON errors = %v, want paths %v", errs, want)
+	} else if !strings.Contains(errs[1].Error(), "不存在的字段") {
+		t.Errorf("TransformJSON error = %v, want 不存在的字段", errs[1])
+	}
+
+	// 根值
+	root, errs := transform.TransformJSON("二十", []FieldRule{{Selector: "", Method: "cn2an", Mode: "normal"}})
+	if root != 20.0 || errs != nil {
+		t.Errorf("TransformJSON(root) = %v, %v, want 20", root, errs)
+	}
+}
+
+func TestTransformCSV(t *testing.T) {
+	input := "name,amount_cn,price\n苹果,一百二十三,58\n香蕉,abc,\n"
+	var builder strings.Builder
+	w := csv.NewWriter(&builder)
+	transform := NewTransform()
+	errs, err := transform.TransformCSV(csv.NewReader(strings.NewReader(input)), w, true, []FieldRule{
+		{Selector: "amount_cn", Method: "cn2an", Mode: "normal"},
+		{Selector: "2", Method: "an2cn", Mode: "up"},
+		{Selector: "weight", Method: "cn2an", Mode: "normal"},
+	})
+	if err != nil {
+		t.Fatalf("TransformCSV error: %v", err)
+	}
+	expected := "name,amount_cn,price\n苹果,123,伍拾捌\n香蕉,abc,\n"
+	if builder.String() != expected {
+		t.Errorf("TransformCSV = %q, want %q", builder.String(), expected)
+	}
+	var paths []string
+	for _, err := range errs {
+		paths = append(paths, err.Path)
+	}
+	if want := []string{"1/weight", "3/amount_cn"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("TransformCSV errors = %v, want paths %v", errs, want)
+	}
+
+	// 无表头
+	builder.Reset()
+	w = csv.NewWriter(&builder)
+	errs, err = transform.TransformCSV(csv.NewReader(strings.NewReader("十,x\n")), w, false, []FieldRule{
+		{Selector: "0", Method: "cn2an", Mode: "normal"},
+		{Selector: "amount", Method: "cn2an", Mode: "normal"},
+	})
+	if err != nil || builder.String() != "10,x\n" || len(errs) != 1 {
+		t.Errorf("TransformCSV(no header) = %q, %v, %v", builder.String(), errs, err)
+	}
+}
@@ -0,0 +1,146 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ordinalModeList 天干地支序数支持的模式：tiangan(甲乙丙), dizhi(子丑寅), ganzhi(甲子至癸亥)
+var ordinalModeList = []string{"tiangan", "dizhi", "ganzhi"}
+
+// WithEraStart 设置干支纪年的起始年份，解析结果落在 [start, start+59] 内；
+// 默认取参考年份之前 50 年至之后 9 年
+func WithEraStart(start int) DateOption {
+	return func(cfg *dateConfig) {
+		cfg.eraStart = start
+	}
+}
+
+// indexOf 返回 s 在 list 中的位置，不存在返回 -1
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// ganzhiIndex 返回干支在六十甲子中的位置（甲子为 0）
+func ganzhiIndex(s string) (int, error) {
+	runes := []rune(s)
+	if len(runes) != 2 {
+		return 0, fmt.Errorf("不符合格式的干支：%s", s)
+	}
+	stem := indexOf(TianganCN, string(runes[0]))
+	branch := indexOf(DizhiCN, string(runes[1]))
+	if stem == -1 || branch == -1 {
+		return 0, fmt.Errorf("不符合格式的干支：%s", s)
+	}
+	if stem%2 != branch%2 {
+		return 0, fmt.Errorf("天干与地支阴阳不符：%s", s)
+	}
+	// 满足 i%10 == stem 且 i%12 == branch 的唯一 i
+	return (6*stem - 5*branch + 60) % 60, nil
+}
+
+// Ganzhi2an 将干支纪年转为公历年份，如 甲辰年 => 2024
+// 年份窗口由 WithEraStart 指定，默认参考 WithDateReference（当前时间）
+func (c *Cn2An) Ganzhi2an(inputs string, opts ...DateOption) (int, error) {
+	cfg := dateConfig{reference: time.Now()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	data := strings.TrimSuffix(strings.TrimSpace(normalizeText(inputs)), "年")
+	index, err := ganzhiIndex(data)
+	if err != nil {
+		return 0, err
+	}
+
+	start := cfg.eraStart
+	if start == 0 {
+		start = cfg.reference.Year() - 50
+	}
+	// 公元 4 年为甲子年
+	offset := ((index+4-start)%60 + 60) % 60
+	return start + offset, nil
+}
+
+// An2ganzhi 将公历年份转为干支纪年，如 2024 => 甲辰
+func (a *An2Cn) An2ganzhi(year int) (string, error) {
+	if year < 1 {
+		return "", fmt.Errorf("年份超出范围：%d", year)
+	}
+	return a.An2cnOrdinal(((year-4)%60+60)%60+1, "ganzhi")
+}
+
+// Zodiac 返回农历年份对应的生肖，如 2024 => 龙
+func Zodiac(year int) string {
+	return ZodiacCN[((year-4)%12+12)%12]
+}
+
+// ZodiacOf 返回公历日期所在农历年的生肖，以正月初一为界
+func ZodiacOf(t time.Time) (string, error) {
+	lunar, err := SolarToLunar(t)
+	if err != nil {
+		return "", err
+	}
+	return Zodiac(lunar.Year), nil
+}
+
+// Cn2anOrdinal 将天干、地支或干支序数转为阿拉伯数字，如 丙 => 3、寅 => 3、甲辰 => 41
+func (c *Cn2An) Cn2anOrdinal(inputs string, mode string) (int, error) {
+	if !contains(ordinalModeList, mode) {
+		return 0, fmt.Errorf("mode 仅支持 %v", ordinalModeList)
+	}
+	data := strings.TrimSpace(normalizeText(inputs))
+	if data == "" {
+		return 0, errors.New("输入数据为空")
+	}
+
+	var index int
+	switch mode {
+	case "tiangan":
+		index = indexOf(TianganCN, data)
+	case "dizhi":
+		index = indexOf(DizhiCN, data)
+	case "ganzhi":
+		i, err := ganzhiIndex(data)
+		if err != nil {
+			return 0, err
+		}
+		index = i
+	}
+	if index == -1 {
+		return 0, fmt.Errorf("不符合格式的序数：%s", inputs)
+	}
+	return index + 1, nil
+}
+
+// An2cnOrdinal 将阿拉伯数字序数转为天干、地支或干支，如 3 => 丙（tiangan）、寅（dizhi）
+func (a *An2Cn) An2cnOrdinal(n int, mode string) (string, error) {
+	if !contains(ordinalModeList, mode) {
+		return "", fmt.Errorf("mode 仅支持 %v", ordinalModeList)
+	}
+
+	switch mode {
+	case "tiangan":
+		if n < 1 || n > len(TianganCN) {
+			return "", fmt.Errorf("天干序数超出范围：%d", n)
+		}
+		return TianganCN[n-1], nil
+	case "dizhi":
+		if n < 1 || n > len(DizhiCN) {
+			return "", fmt.Errorf("地支序数超出范围：%d", n)
+		}
+		return DizhiCN[n-1], nil
+	default:
+		if n < 1 || n > 60 {
+			return "", fmt.Errorf("干支序数超出范围：%d", n)
+		}
+		return TianganCN[(n-1)%10] + DizhiCN[(n-1)%12], nil
+	}
+}
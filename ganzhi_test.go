@@ -0,0 +1,142 @@
+package gocn2an
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGanzhi2an(t *testing.T) {
+	reference := WithDateReference(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	testData := map[string]int{
+		"甲辰年": 2024,
+		"甲辰":  2024,
+		"乙巳年": 2025,
+		"庚子年": 2020,
+		"丙午年": 2026,
+		"乙卯年": 1975,
+		"甲子年": 1984,
+		"癸卯年": 2023,
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.Ganzhi2an(input, reference)
+		if err != nil {
+			t.Errorf("Ganzhi2an(%q) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Ganzhi2an(%q) = %d, want %d", input, result, expected)
+		}
+	}
+
+	result, err := c.Ganzhi2an("辛亥年", WithEraStart(1900))
+	if err != nil || result != 1911 {
+		t.Errorf("Ganzhi2an(%q) with era start 1900 = %d, %v, want 1911", "辛亥年", result, err)
+	}
+
+	for _, input := range []string{"", "甲丑年", "甲年", "子甲"} {
+		if _, err := c.Ganzhi2an(input, reference); err == nil {
+			t.Errorf("Ganzhi2an(%q) should return error but got nil", input)
+		}
+	}
+}
+
+func TestAn2ganzhi(t *testing.T) {
+	testData := map[int]string{
+		2024: "甲辰",
+		1984: "甲子",
+		1911: "辛亥",
+		2043: "癸亥",
+		4:    "甲子",
+		3:    "癸亥",
+		1:    "辛酉",
+	}
+
+	a := NewAn2Cn()
+	for input, expected := range testData {
+		result, err := a.An2ganzhi(input)
+		if err != nil {
+			t.Errorf("An2ganzhi(%d) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("An2ganzhi(%d) = %s, want %s", input, result, expected)
+		}
+	}
+
+	if _, err := a.An2ganzhi(0); err == nil {
+		t.Errorf("An2ganzhi(0) should return error but got nil")
+	}
+}
+
+func TestZodiac(t *testing.T) {
+	testData := map[int]string{2024: "龙", 2020: "鼠", 1984: "鼠", 2023: "兔", 1900: "鼠"}
+	for input, expected := range testData {
+		if result := Zodiac(input); result != expected {
+			t.Errorf("Zodiac(%d) = %s, want %s", input, result, expected)
+		}
+	}
+
+	// 2024-02-09 为癸卯年除夕，2024-02-10 为甲辰年正月初一
+	for date, expected := range map[string]string{"2024-02-09": "兔", "2024-02-10": "龙"} {
+		day, _ := time.Parse("2006-01-02", date)
+		result, err := ZodiacOf(day)
+		if err != nil {
+			t.Errorf("ZodiacOf(%s) error: %v", date, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("ZodiacOf(%s) = %s, want %s", date, result, expected)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	testData := []struct {
+		input    string
+		mode     string
+		expected int
+	}{
+		{"甲", "tiangan", 1},
+		{"丙", "tiangan", 3},
+		{"癸", "tiangan", 10},
+		{"子", "dizhi", 1},
+		{"寅", "dizhi", 3},
+		{"亥", "dizhi", 12},
+		{"甲子", "ganzhi", 1},
+		{"甲辰", "ganzhi", 41},
+		{"癸亥", "ganzhi", 60},
+	}
+
+	c := NewCn2An()
+	a := NewAn2Cn()
+	for _, item := range testData {
+		result, err := c.Cn2anOrdinal(item.input, item.mode)
+		if err != nil {
+			t.Errorf("Cn2anOrdinal(%q, %s) error: %v", item.input, item.mode, err)
+			continue
+		}
+		if result != item.expected {
+			t.Errorf("Cn2anOrdinal(%q, %s) = %d, want %d", item.input, item.mode, result, item.expected)
+		}
+		back, err := a.An2cnOrdinal(item.expected, item.mode)
+		if err != nil {
+			t.Errorf("An2cnOrdinal(%d, %s) error: %v", item.expected, item.mode, err)
+			continue
+		}
+		if back != item.input {
+			t.Errorf("An2cnOrdinal(%d, %s) = %s, want %s", item.expected, item.mode, back, item.input)
+		}
+	}
+
+	if _, err := c.Cn2anOrdinal("子", "tiangan"); err == nil {
+		t.Errorf("Cn2anOrdinal(%q, tiangan) should return error but got nil", "子")
+	}
+	if _, err := a.An2cnOrdinal(11, "tiangan"); err == nil {
+		t.Errorf("An2cnOrdinal(11, tiangan) should return error but got nil")
+	}
+	if _, err := a.An2cnOrdinal(1, "roman"); err == nil {
+		t.Errorf("An2cnOrdinal(1, roman) should return error but got nil")
+	}
+}
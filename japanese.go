@@ -0,0 +1,273 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// jaModeList 日文相关的 An2cn 模式
+var jaModeList = []string{"ja", "ja_daiji", "ja_yen"}
+
+// isJaMode 判断是否为日文输出模式
+func isJaMode(mode string) bool {
+	return contains(jaModeList, mode)
+}
+
+// jaCn2an 解析日文汉数字（含大字、金…円也 金额写法）
+// 日文不插入零：千一 => 1001，一万五十 => 10050
+func (c *Cn2An) jaCn2an(inputs string) (float64, error) {
+	data := strings.TrimSpace(inputs)
+	data = strings.TrimPrefix(data, "金")
+	for _, word := range []string{"也", "整", "正"} {
+		data = strings.TrimSuffix(data, word)
+	}
+
+	sign := 1.0
+	for _, prefix := range []string{"マイナス", "负", "-"} {
+		if strings.HasPrefix(data, prefix) {
+			data = strings.TrimPrefix(data, prefix)
+			sign = -1
+			break
+		}
+	}
+
+	if data == "" {
+		return 0, fmt.Errorf("不符合格式的数据：%s", inputs)
+	}
+
+	// 円/銭 金额
+	if idx := strings.IndexAny(data, "円圆元"); idx != -1 {
+		yenPart := data[:idx]
+		_, size := utf8.DecodeRuneInString(data[idx:])
+		senPart := strings.TrimSuffix(data[idx+size:], "銭")
+		if yenPart == "" && senPart == "" {
+			return 0, fmt.Errorf("不符合格式的数据：%s", inputs)
+		}
+		var yen, sen int64
+		var err error
+		if yenPart != "" {
+			if yen, err = jaIntegerConvert(yenPart); err != nil {
+				return 0, err
+			}
+		}
+		if senPart != "" {
+			if sen, err = jaIntegerConvert(senPart); err != nil {
+				return 0, err
+			}
+			if sen >= 100 {
+				return 0, fmt.Errorf("銭的数值超出范围：%s", inputs)
+			}
+		}
+		return sign * roundToDecimal(float64(yen)+float64(sen)/100, 2), nil
+	}
+
+	// 小数
+	data = strings.NewReplacer("・", "点", ".", "点").Replace(data)
+	parts := strings.Split(data, "点")
+	if len(parts) > 2 || parts[0] == "" || (len(parts) == 2 && parts[1] == "") {
+		return 0, fmt.Errorf("不符合格式的数据：%s", inputs)
+	}
+
+	intVal, err := jaIntegerConvert(parts[0])
+	if err != nil {
+		return 0, err
+	}
+	output := float64(intVal)
+	if len(parts) == 2 {
+		decimalData, err := jaDigitsToCn(parts[1])
+		if err != nil {
+			return 0, err
+		}
+		decVal, err := c.decimalConvert(decimalData)
+		if err != nil {
+			return 0, err
+		}
+		output = roundToDecimal(output+decVal, len([]rune(decimalData)))
+	}
+
+	return sign * output, nil
+}
+
+// jaIntegerConvert 解析日文汉数字整数部分
+func jaIntegerConvert(data string) (int64, error) {
+	runes := []rune(data)
+	hasUnit := false
+	for _, r := range runes {
+		if _, ok := UnitJACN2AN[r]; ok {
+			hasUnit = true
+			break
+		}
+	}
+
+	// 位置记数：二〇二四
+	if !hasUnit {
+		var output int64
+		for _, r := range runes {
+			num, ok := NumberJACN2AN[r]
+			if !ok {
+				return 0, fmt.Errorf("%c 不在转化范围内", r)
+			}
+			output = output*10 + int64(num)
+		}
+		return output, nil
+	}
+
+	var total, section int64
+	digit := -1
+	lastSmall := int64(10000)
+	lastLarge := int64(1) << 62
+	for _, r := range runes {
+		if num, ok := NumberJACN2AN[r]; ok {
+			// 兼容中文写法中的零：一千零一
+			if num == 0 {
+				continue
+			}
+			if digit != -1 {
+				return 0, fmt.Errorf("不符合格式的数据：%s", data)
+			}
+			digit = num
+			continue
+		}
+		unit, ok := UnitJACN2AN[r]
+		if !ok {
+			return 0, fmt.Errorf("%c 不在转化范围内", r)
+		}
+		if unit < 10000 {
+			if unit >= lastSmall {
+				return 0, fmt.Errorf("不符合格式的数据：%s", data)
+			}
+			n := int64(1)
+			if digit != -1 {
+				n = int64(digit)
+			}
+			section += n * unit
+			lastSmall = unit
+		} else {
+			if digit != -1 {
+				section += int64(digit)
+			}
+			if section == 0 || unit >= lastLarge {
+				return 0, fmt.Errorf("不符合格式的数据：%s", data)
+			}
+			total += section * unit
+			section = 0
+			lastSmall = 10000
+			lastLarge = unit
+		}
+		digit = -1
+	}
+	if digit != -1 {
+		section += int64(digit)
+	}
+
+	return total + section, nil
+}
+
+// jaDigitsToCn 将日文小数位数字转为中文小写数字，便于复用 decimalConvert
+func jaDigitsToCn(data string) (string, error) {
+	var builder strings.Builder
+	for _, r := range data {
+		num, ok := NumberJACN2AN[r]
+		if !ok {
+			return "", fmt.Errorf("%c 不在转化范围内", r)
+		}
+		builder.WriteString(NumberLowAN2CN[num])
+	}
+	return builder.String(), nil
+}
+
+// jaConvert 阿拉伯数字转日文汉数字
+// inputStr 为去掉符号后的数字字符串
+func (a *An2Cn) jaConvert(inputStr, mode string) (string, error) {
+	parts := strings.Split(inputStr, ".")
+	if len(parts) > 2 || parts[0] == "" {
+		return "", fmt.Errorf("输入格式错误：%s", inputStr)
+	}
+
+	daiji := mode != "ja"
+	intOut, err := jaIntegerFormat(parts[0], daiji)
+	if err != nil {
+		return "", err
+	}
+
+	decimalData := ""
+	if len(parts) == 2 {
+		decimalData = parts[1]
+	}
+
+	if mode == "ja_yen" {
+		output := "金" + intOut + "円"
+		senData := (decimalData + "00")[:2]
+		if senData != "00" {
+			senOut, err := jaIntegerFormat(senData, true)
+			if err != nil {
+				return "", err
+			}
+			output += senOut + "銭"
+		}
+		return output + "也", nil
+	}
+
+	if decimalData == "" {
+		return intOut, nil
+	}
+	if len(decimalData) > 16 {
+		decimalData = decimalData[:16]
+	}
+	numeralList := NumberJaAN2CN
+	if daiji {
+		numeralList = NumberJaDaijiAN2CN
+	}
+	var builder strings.Builder
+	builder.WriteString(intOut)
+	builder.WriteString("点")
+	for _, ch := range decimalData {
+		builder.WriteString(numeralList[int(ch-'0')])
+	}
+	return builder.String(), nil
+}
+
+// jaIntegerFormat 按日文习惯输出整数：不插入零，常用写法省略十百千前的一，大字写法保留壱
+func jaIntegerFormat(integerData string, daiji bool) (string, error) {
+	integerData = strings.TrimLeft(integerData, "0")
+	if integerData == "" {
+		return NumberJaAN2CN[0], nil
+	}
+
+	numeralList, sectionUnits, largeUnits := NumberJaAN2CN, UnitJaSectionAN2CN, UnitJaLargeAN2CN
+	if daiji {
+		numeralList, sectionUnits, largeUnits = NumberJaDaijiAN2CN, UnitJaDaijiSectionAN2CN, UnitJaDaijiLargeAN2CN
+	}
+
+	lenInteger := len(integerData)
+	if lenInteger > 4*len(largeUnits) {
+		return "", fmt.Errorf("超出数据范围，最长支持 %d 位", 4*len(largeUnits))
+	}
+
+	var builder strings.Builder
+	sectionHasValue := false
+	for i, ch := range integerData {
+		pos := lenInteger - i - 1
+		d := int(ch - '0')
+		if d != 0 {
+			sectionHasValue = true
+			if d != 1 || daiji || pos%4 == 0 {
+				builder.WriteString(numeralList[d])
+			}
+			builder.WriteString(sectionUnits[pos%4])
+		}
+		if pos%4 == 0 {
+			if sectionHasValue {
+				builder.WriteString(largeUnits[pos/4])
+			}
+			sectionHasValue = false
+		}
+	}
+
+	if builder.Len() == 0 {
+		return "", errors.New("异常输出")
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,100 @@
+package gocn2an
+
+import (
+	"testing"
+)
+
+func TestCn2anJa(t *testing.T) {
+	testData := map[string]float64{
+		"〇":        0,
+		"千一":       1001,
+		"一千零一":     1001,
+		"二千二十四":    2024,
+		"二〇二四":     2024,
+		"一万五十":     10050,
+		"十万":       100000,
+		"壱萬":       10000,
+		"弐阡参百":     2300,
+		"参拾":       30,
+		"一億二千万":    120000000,
+		"一兆二億":     1000200000000,
+		"金壱萬円也":    10000,
+		"金参千円":     3000,
+		"百円五十銭":    100.5,
+		"三点一四":     3.14,
+		"三・〇五":     3.05,
+		"マイナス五":    -5,
+		"九千九百九十九萬": 99990000,
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.Cn2an(input, "ja")
+		if err != nil {
+			t.Errorf("Cn2an(%q, ja) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Cn2an(%q, ja) = %f, want %f", input, result, expected)
+		}
+	}
+}
+
+func TestCn2anJaError(t *testing.T) {
+	errorData := []string{
+		"万",
+		"十百",
+		"一二十",
+		"一万一億",
+		"三点",
+		"金円也",
+	}
+
+	c := NewCn2An()
+	for _, input := range errorData {
+		_, err := c.Cn2an(input, "ja")
+		if err == nil {
+			t.Errorf("Cn2an(%q, ja) should return error but got nil", input)
+		}
+	}
+}
+
+func TestAn2cnJa(t *testing.T) {
+	testData := map[string]map[interface{}]string{
+		"ja": {
+			0:             "〇",
+			1001:          "千一",
+			2024:          "二千二十四",
+			10050:         "一万五十",
+			110000:        "十一万",
+			120000000:     "一億二千万",
+			1000200000000: "一兆二億",
+			-5:            "マイナス五",
+			3.05:          "三点〇五",
+		},
+		"ja_daiji": {
+			10000: "壱萬",
+			1234:  "壱千弐百参拾四",
+			30:    "参拾",
+		},
+		"ja_yen": {
+			10000: "金壱萬円也",
+			3000:  "金参千円也",
+			100.5: "金壱百円五拾銭也",
+		},
+	}
+
+	a := NewAn2Cn()
+	for mode, cases := range testData {
+		for input, expected := range cases {
+			result, err := a.An2cn(input, mode)
+			if err != nil {
+				t.Errorf("An2cn(%v, %s) error: %v", input, mode, err)
+				continue
+			}
+			if result != expected {
+				t.Errorf("An2cn(%v, %s) = %s, want %s", input, mode, result, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package gocn2an
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// termPlaceholderBase 词表命中位置的占位符起点（补充私用区），转换结束后还原
+const termPlaceholderBase = 0xF0000
+
+// WithProtectedTerms 在 cn2an 句子转换中追加不做数字转换的词语，如 品牌名、地名
+func WithProtectedTerms(terms ...string) TransformOption {
+	return func(t *Transform) {
+		for _, term := range terms {
+			if term != "" {
+				t.lexicon[term] = lexiconEntry{output: term, protect: true}
+			}
+		}
+	}
+}
+
+// WithForcedTerms 在 cn2an 句子转换中将词语固定替换为给定写法，如 双十一 => 双11，优先于内置词表
+func WithForcedTerms(terms map[string]string) TransformOption {
+	return func(t *Transform) {
+		for term, output := range terms {
+			if term != "" {
+				t.lexicon[term] = lexiconEntry{output: output, protect: true}
+			}
+		}
+	}
+}
+
+// lexiconEntry 词表条目：protect 为 false 时只占位，原文仍按数字转换（如 三星期）
+type lexiconEntry struct {
+	output  string
+	protect bool
+}
+
+// builtinLexicon 内置词表
+func builtinLexicon() map[string]lexiconEntry {
+	lexicon := make(map[string]lexiconEntry, len(ProtectedTermsCN)+len(NumericTermsCN))
+	for _, term := range ProtectedTermsCN {
+		lexicon[term] = lexiconEntry{output: term, protect: true}
+	}
+	for _, term := range NumericTermsCN {
+		lexicon[term] = lexiconEntry{output: term}
+	}
+	return lexicon
+}
+
+// termMatch 词表命中，start、end 为字节偏移
+type termMatch struct {
+	start, end int
+	term       int
+}
+
+// acNode Aho-Corasick 自动机节点
+type acNode struct {
+	next map[rune]int
+	fail int
+	// term 以该节点结尾的词条下标，-1 表示无
+	term int
+	// dict 沿失败链最近的词条节点，-1 表示无
+	dict int
+}
+
+// termMatcher 基于 Aho-Corasick 的多词匹配器
+type termMatcher struct {
+	nodes []acNode
+	terms []string
+}
+
+// newTermMatcher 构建匹配器
+func newTermMatcher(terms []string) *termMatcher {
+	m := &termMatcher{nodes: []acNode{{next: map[rune]int{}, term: -1, dict: -1}}, terms: terms}
+	for i, term := range terms {
+		node := 0
+		for _, r := range term {
+			child, ok := m.nodes[node].next[r]
+			if !ok {
+				child = len(m.nodes)
+				m.nodes = append(m.nodes, acNode{next: map[rune]int{}, term: -1, dict: -1})
+				m.nodes[node].next[r] = child
+			}
+			node = child
+		}
+		m.nodes[node].term = i
+	}
+
+	// 按层构建失败链
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[node].next {
+			fail := m.nodes[node].fail
+			for fail != 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if next, ok := m.nodes[fail].next[r]; ok && next != child {
+				m.nodes[child].fail = next
+			}
+			failNode := m.nodes[child].fail
+			if m.nodes[failNode].term != -1 {
+				m.nodes[child].dict = failNode
+			} else {
+				m.nodes[child].dict = m.nodes[failNode].dict
+			}
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+// findAll 返回互不重叠的命中，同一起点取最长，从左到右
+func (m *termMatcher) findAll(s string) []termMatch {
+	var matches []termMatch
+	node := 0
+	for i, r := range s {
+		for node != 0 {
+			if _, ok := m.nodes[node].next[r]; ok {
+				break
+			}
+			node = m.nodes[node].fail
+		}
+		if next, ok := m.nodes[node].next[r]; ok {
+			node = next
+		}
+		end := i + utf8.RuneLen(r)
+		for out := node; out != -1; out = m.nodes[out].dict {
+			if term := m.nodes[out].term; term != -1 {
+				matches = append(matches, termMatch{start: end - len(m.terms[term]), end: end, term: term})
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+	selected := matches[:0]
+	last := 0
+	for _, match := range matches {
+		if match.start >= last {
+			selected = append(selected, match)
+			last = match.end
+		}
+	}
+	return selected
+}
+
+// protectedSpan 需要保持原样或固定替换的片段，start、end 为字节偏移
+type protectedSpan struct {
+	start, end int
+	output     string
+}
+
+// lexiconSpans 返回词表命中的片段；紧跟在中文数字之后的命中不生效，如 一万一 中的 万一
+func (t *Transform) lexiconSpans(s string) []protectedSpan {
+	var spans []protectedSpan
+	for _, match := range t.termMatcher.findAll(s) {
+		if prev, _ := utf8.DecodeLastRuneInString(s[:match.start]); match.start > 0 && strings.ContainsRune(t.allNum+t.allUnit, prev) {
+			continue
+		}
+		if entry := t.lexicon[t.termMatcher.terms[match.term]]; entry.protect {
+			spans = append(spans, protectedSpan{start: match.start, end: match.end, output: entry.output})
+		}
+	}
+	return spans
+}
+
+// protectTerms 将词表及分词器给出的片段替换为占位符，返回替换后的文本和各占位符对应的输出；
+// 片段重叠时词表优先，替换位置记录到 rec
+func (t *Transform) protectTerms(s string, rec *alignmentRecorder) (string, []string) {
+	spans := t.lexiconSpans(s)
+	if t.segmenter != nil {
+		spans = append(spans, t.segmenterSpans(s)...)
+		sort.SliceStable(spans, func(i, j int) bool {
+			return spans[i].start < spans[j].start
+		})
+	}
+
+	var builder strings.Builder
+	var outputs []string
+	var edits []textEdit
+	last := 0
+	for _, span := range spans {
+		if span.start < last {
+			continue
+		}
+		placeholder := string(rune(termPlaceholderBase + len(outputs)))
+		builder.WriteString(s[last:span.start])
+		builder.WriteString(placeholder)
+		outputs = append(outputs, span.output)
+		edits = append(edits, textEdit{start: span.start, end: span.end, text: placeholder})
+		last = span.end
+	}
+	if outputs == nil {
+		return s, nil
+	}
+	builder.WriteString(s[last:])
+	result := builder.String()
+	rec.record(result, edits)
+	return result, outputs
+}
+
+// restoreTerms 还原 protectTerms 写入的占位符，还原位置记录到 rec
+func restoreTerms(s string, outputs []string, rec *alignmentRecorder) string {
+	if outputs == nil {
+		return s
+	}
+	var builder strings.Builder
+	var edits []textEdit
+	last := 0
+	for i, r := range s {
+		index := int(r) - termPlaceholderBase
+		if index < 0 || index >= len(outputs) {
+			continue
+		}
+		size := utf8.RuneLen(r)
+		builder.WriteString(s[last:i])
+		builder.WriteString(outputs[index])
+		edits = append(edits, textEdit{start: i, end: i + size, text: outputs[index]})
+		last = i + size
+	}
+	builder.WriteString(s[last:])
+	result := builder.String()
+	rec.record(result, edits)
+	return result
+}
@@ -0,0 +1,28 @@
+package gocn2an
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTermMatcher(t *testing.T) {
+	m := newTermMatcher([]string{"三星", "三星期", "星期", "四川", "川菜"})
+	testData := map[string][]string{
+		"三星手机":   {"三星"},
+		"工期三星期":  {"三星期"},
+		"四川菜":    {"四川"},
+		"吃川菜":    {"川菜"},
+		"下星期去四川": {"星期", "四川"},
+		"没有命中":   nil,
+	}
+
+	for input, expected := range testData {
+		var got []string
+		for _, match := range m.findAll(input) {
+			got = append(got, input[match.start:match.end])
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("findAll(%q) = %v, want %v", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LunarDate 农历日期
+type LunarDate struct {
+	Year  int
+	Month int
+	Day   int
+	Leap  bool // 闰月
+}
+
+// lunarInfo 农历 1900-2100 年数据：
+// 低 4 位为闰月月份（0 表示无闰月），第 4-15 位自高到低依次为正月至腊月是否为大月（30 天），
+// 第 16 位表示闰月是否为大月
+var lunarInfo = [...]uint32{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, // 1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040-2049
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, // 2050-2059
+	0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, // 2060-2069
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070-2079
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, // 2080-2089
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, // 2090-2099
+	0x0d520, // 2100
+}
+
+const (
+	lunarMinYear = 1900
+	lunarMaxYear = 1900 + len(lunarInfo) - 1
+)
+
+// lunarEpoch 农历 1900 年正月初一
+var lunarEpoch = time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+
+// LunarMonthNameCN 农历月份名称，十一月、十二月习惯称冬月、腊月
+var LunarMonthNameCN = map[int]string{
+	1: "正", 2: "二", 3: "三", 4: "四", 5: "五", 6: "六",
+	7: "七", 8: "八", 9: "九", 10: "十", 11: "冬", 12: "腊",
+}
+
+// lunarMonthAlias 农历月份别称
+var lunarMonthAlias = map[string]int{
+	"正": 1,
+	"元": 1,
+	"冬": 11,
+	"腊": 12,
+	"臘": 12,
+}
+
+// lunarLeapMonth 返回该年闰几月，无闰月返回 0
+func lunarLeapMonth(year int) int {
+	return int(lunarInfo[year-lunarMinYear] & 0xf)
+}
+
+// lunarMonthDays 返回该年某月的天数
+func lunarMonthDays(year, month int, leap bool) int {
+	info := lunarInfo[year-lunarMinYear]
+	if leap {
+		if info&0x10000 != 0 {
+			return 30
+		}
+		return 29
+	}
+	if info&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// lunarYearDays 返回该年的天数
+func lunarYearDays(year int) int {
+	days := 0
+	for month := 1; month <= 12; month++ {
+		days += lunarMonthDays(year, month, false)
+	}
+	if leap := lunarLeapMonth(year); leap != 0 {
+		days += lunarMonthDays(year, leap, true)
+	}
+	return days
+}
+
+// Validate 校验农历日期是否在数据范围内且存在
+func (d LunarDate) Validate() error {
+	if d.Year < lunarMinYear || d.Year > lunarMaxYear {
+		return fmt.Errorf("农历年份仅支持 %d-%d：%d", lunarMinYear, lunarMaxYear, d.Year)
+	}
+	if d.Month < 1 || d.Month > 12 {
+		return fmt.Errorf("农历月份超出范围：%d", d.Month)
+	}
+	if d.Leap && lunarLeapMonth(d.Year) != d.Month {
+		return fmt.Errorf("农历 %d 年没有闰%s月", d.Year, LunarMonthNameCN[d.Month])
+	}
+	if days := lunarMonthDays(d.Year, d.Month, d.Leap); d.Day < 1 || d.Day > days {
+		return fmt.Errorf("农历 %d 年%s月只有 %d 天", d.Year, LunarMonthNameCN[d.Month], days)
+	}
+	return nil
+}
+
+// Solar 返回农历日期对应的公历日期
+func (d LunarDate) Solar(loc *time.Location) (time.Time, error) {
+	if err := d.Validate(); err != nil {
+		return time.Time{}, err
+	}
+
+	offset := 0
+	for year := lunarMinYear; year < d.Year; year++ {
+		offset += lunarYearDays(year)
+	}
+	leap := lunarLeapMonth(d.Year)
+	for month := 1; month < d.Month; month++ {
+		offset += lunarMonthDays(d.Year, month, false)
+		if month == leap {
+			offset += lunarMonthDays(d.Year, month, true)
+		}
+	}
+	if d.Leap {
+		offset += lunarMonthDays(d.Year, d.Month, false)
+	}
+	offset += d.Day - 1
+
+	solar := lunarEpoch.AddDate(0, 0, offset)
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(solar.Year(), solar.Month(), solar.Day(), 0, 0, 0, 0, loc), nil
+}
+
+// SolarToLunar 返回公历日期对应的农历日期，支持农历 1900-2100 年
+func SolarToLunar(t time.Time) (LunarDate, error) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := int(day.Sub(lunarEpoch).Hours() / 24)
+	if offset < 0 {
+		return LunarDate{}, fmt.Errorf("日期早于农历 %d 年正月初一：%s", lunarMinYear, t.Format("2006-01-02"))
+	}
+
+	year := lunarMinYear
+	for ; year <= lunarMaxYear; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+	if year > lunarMaxYear {
+		return LunarDate{}, fmt.Errorf("日期晚于农历 %d 年除夕：%s", lunarMaxYear, t.Format("2006-01-02"))
+	}
+
+	leap := lunarLeapMonth(year)
+	for month := 1; month <= 12; month++ {
+		days := lunarMonthDays(year, month, false)
+		if offset < days {
+			return LunarDate{Year: year, Month: month, Day: offset + 1}, nil
+		}
+		offset -= days
+		if month == leap {
+			days = lunarMonthDays(year, month, true)
+			if offset < days {
+				return LunarDate{Year: year, Month: month, Day: offset + 1, Leap: true}, nil
+			}
+			offset -= days
+		}
+	}
+	return LunarDate{}, errors.New("农历数据异常")
+}
+
+const (
+	lunarPrefixPattern = `农历|阴历|農曆|陰曆|农|農`
+	lunarDayPattern    = `初[一二三四五六七八九十]|[十廿卅二三]?十?[一二三四五六七八九十]|3[01]|[12]?[0-9]`
+)
+
+var (
+	// 农历正月初一、腊月廿三、闰四月十五、二〇二三年闰二月初十
+	lunarDateRe = regexp.MustCompile(fmt.Sprintf(`^(%[1]s)?(?:([0-9零〇一二三四五六七八九十百千两]+)年)?(闰|閏)?(正|元|冬|腊|臘|十[一二]|[一二三四五六七八九十]|1[0-2]|[1-9])月(%[2]s)[日号號]?$`,
+		lunarPrefixPattern, lunarDayPattern))
+	lunarTextRe = regexp.MustCompile(fmt.Sprintf(`(?:%[1]s)?(?:[0-9零〇一二三四五六七八九十百千两]+年)?[闰閏]?(?:正|元|冬|腊|臘|十[一二]|[一二三四五六七八九十]|1[0-2]|[1-9])月(?:%[2]s)[日号號]?`,
+		lunarPrefixPattern, lunarDayPattern))
+)
+
+// ParseLunarDate 解析农历日期，如 农历正月初一、腊月廿三、闰四月十五、二〇二三年闰二月初十
+// 未写年份时取 WithDateReference 参考时间所在的农历年
+func (c *Cn2An) ParseLunarDate(inputs string, opts ...DateOption) (LunarDate, error) {
+	cfg := dateConfig{pivot: 50, reference: time.Now()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	data := strings.TrimSpace(normalizeText(inputs))
+	subs := lunarDateRe.FindStringSubmatch(data)
+	if subs == nil {
+		return LunarDate{}, fmt.Errorf("不符合格式的农历日期：%s", inputs)
+	}
+
+	var d LunarDate
+	var err error
+	if subs[2] != "" {
+		if d.Year, err = c.dateYear(subs[2], cfg.pivot); err != nil {
+			return LunarDate{}, err
+		}
+	} else {
+		ref, err := SolarToLunar(cfg.reference)
+		if err != nil {
+			return LunarDate{}, err
+		}
+		d.Year = ref.Year
+	}
+	d.Leap = subs[3] != ""
+	if month, ok := lunarMonthAlias[subs[4]]; ok {
+		d.Month = month
+	} else if d.Month, err = c.timeNumber(subs[4]); err != nil {
+		return LunarDate{}, err
+	}
+	if d.Day, err = c.timeNumber(strings.TrimPrefix(subs[5], "初")); err != nil {
+		return LunarDate{}, err
+	}
+
+	if err := d.Validate(); err != nil {
+		return LunarDate{}, err
+	}
+	return d, nil
+}
+
+// An2cnLunar 将农历日期转为中文，如 二零二四年正月初一、闰四月十五、腊月廿三
+// fields 指定输出的字段，为 0 时输出年、月、日
+func (a *An2Cn) An2cnLunar(d LunarDate, fields DateFields) (string, error) {
+	if err := d.Validate(); err != nil {
+		return "", err
+	}
+	if fields&(DateHasYear|DateHasMonth|DateHasDay) == 0 {
+		fields = DateHasYear | DateHasMonth | DateHasDay
+	}
+
+	var builder strings.Builder
+	if fields&DateHasYear != 0 {
+		year, err := a.An2cn(d.Year, "direct")
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(year + "年")
+	}
+	if fields&DateHasMonth != 0 {
+		if d.Leap {
+			builder.WriteString("闰")
+		}
+		builder.WriteString(LunarMonthNameCN[d.Month] + "月")
+	}
+	if fields&DateHasDay != 0 {
+		day, err := a.An2cn(d.Day, "low")
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case d.Day <= 10:
+			day = "初" + day
+		case d.Day > 20 && d.Day < 30:
+			day = strings.Replace(day, "二十", "廿", 1)
+		}
+		builder.WriteString(day)
+	}
+	if a.traditional {
+		return toTraditionalScript(builder.String()), nil
+	}
+	return builder.String(), nil
+}
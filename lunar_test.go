@@ -0,0 +1,114 @@
+package gocn2an
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLunarSolarConversion(t *testing.T) {
+	testData := []struct {
+		lunar LunarDate
+		solar string
+	}{
+		{LunarDate{Year: 1900, Month: 1, Day: 1}, "1900-01-31"},
+		{LunarDate{Year: 1980, Month: 1, Day: 1}, "1980-02-16"},
+		{LunarDate{Year: 2000, Month: 1, Day: 1}, "2000-02-05"},
+		{LunarDate{Year: 2020, Month: 4, Day: 15, Leap: true}, "2020-06-06"},
+		{LunarDate{Year: 2023, Month: 2, Day: 10, Leap: true}, "2023-03-31"},
+		{LunarDate{Year: 2023, Month: 12, Day: 30}, "2024-02-09"},
+		{LunarDate{Year: 2024, Month: 1, Day: 1}, "2024-02-10"},
+		{LunarDate{Year: 2024, Month: 8, Day: 15}, "2024-09-17"},
+		{LunarDate{Year: 2033, Month: 1, Day: 1}, "2033-01-31"},
+		{LunarDate{Year: 2100, Month: 1, Day: 1}, "2100-02-09"},
+	}
+
+	for _, item := range testData {
+		solar, err := item.lunar.Solar(time.UTC)
+		if err != nil {
+			t.Errorf("Solar(%+v) error: %v", item.lunar, err)
+			continue
+		}
+		if got := solar.Format("2006-01-02"); got != item.solar {
+			t.Errorf("Solar(%+v) = %s, want %s", item.lunar, got, item.solar)
+		}
+		lunar, err := SolarToLunar(solar)
+		if err != nil {
+			t.Errorf("SolarToLunar(%s) error: %v", item.solar, err)
+			continue
+		}
+		if lunar != item.lunar {
+			t.Errorf("SolarToLunar(%s) = %+v, want %+v", item.solar, lunar, item.lunar)
+		}
+	}
+
+	if _, err := SolarToLunar(time.Date(1900, 1, 30, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("SolarToLunar before 1900 should return error but got nil")
+	}
+	for _, d := range []LunarDate{{Year: 2024, Month: 4, Day: 1, Leap: true}, {Year: 2024, Month: 1, Day: 31}, {Year: 2101, Month: 1, Day: 1}} {
+		if err := d.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should return error but got nil", d)
+		}
+	}
+}
+
+func TestParseLunarDate(t *testing.T) {
+	reference := WithDateReference(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	testData := map[string]LunarDate{
+		"农历正月初一":     {Year: 2024, Month: 1, Day: 1},
+		"腊月廿三":       {Year: 2024, Month: 12, Day: 23},
+		"冬月三十":       {Year: 2024, Month: 11, Day: 30},
+		"农历八月十五":     {Year: 2024, Month: 8, Day: 15},
+		"農曆臘月初八":     {Year: 2024, Month: 12, Day: 8},
+		"二〇二三年闰二月初十": {Year: 2023, Month: 2, Day: 10, Leap: true},
+		"二〇二〇年閏四月十五": {Year: 2020, Month: 4, Day: 15, Leap: true},
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.ParseLunarDate(input, reference)
+		if err != nil {
+			t.Errorf("ParseLunarDate(%q) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("ParseLunarDate(%q) = %+v, want %+v", input, result, expected)
+		}
+	}
+
+	for _, input := range []string{"闰四月十五", "正月三十一", "十三月初一", "正月"} {
+		if _, err := c.ParseLunarDate(input, reference); err == nil {
+			t.Errorf("ParseLunarDate(%q) should return error but got nil", input)
+		}
+	}
+}
+
+func TestAn2cnLunar(t *testing.T) {
+	testData := []struct {
+		lunar    LunarDate
+		fields   DateFields
+		expected string
+	}{
+		{LunarDate{Year: 2024, Month: 1, Day: 1}, 0, "二零二四年正月初一"},
+		{LunarDate{Year: 2024, Month: 12, Day: 23}, DateHasMonth | DateHasDay, "腊月廿三"},
+		{LunarDate{Year: 2020, Month: 4, Day: 15, Leap: true}, DateHasMonth | DateHasDay, "闰四月十五"},
+		{LunarDate{Year: 2024, Month: 11, Day: 20}, DateHasMonth | DateHasDay, "冬月二十"},
+		{LunarDate{Year: 2024, Month: 8, Day: 10}, DateHasDay, "初十"},
+	}
+
+	a := NewAn2Cn()
+	for _, item := range testData {
+		result, err := a.An2cnLunar(item.lunar, item.fields)
+		if err != nil {
+			t.Errorf("An2cnLunar(%+v) error: %v", item.lunar, err)
+			continue
+		}
+		if result != item.expected {
+			t.Errorf("An2cnLunar(%+v) = %s, want %s", item.lunar, result, item.expected)
+		}
+	}
+
+	result, _ := NewAn2Cn(WithTraditionalScript()).An2cnLunar(LunarDate{Year: 2020, Month: 4, Day: 8, Leap: true}, DateHasMonth|DateHasDay)
+	if result != "閏四月初八" {
+		t.Errorf("An2cnLunar with traditional script = %s, want %s", result, "閏四月初八")
+	}
+}
@@ -0,0 +1,508 @@
+package gocn2an
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TransformHTML 转换 HTML 中的可见文本，method 为 cn2an 或 an2cn；
+// 标签、属性、注释、字符实体、网址以及 script、style、code、pre 等元素的内容保持原样
+func (t *Transform) TransformHTML(inputs, method string) (string, error) {
+	output, _, err := t.transformMarkup(inputs, method, htmlSpans(inputs), false)
+	return output, err
+}
+
+// TransformMarkdown 转换 Markdown 中的可见文本，method 为 cn2an 或 an2cn；
+// 代码块、行内代码、链接地址、引用定义、脚注标记、内嵌 HTML 标签、网址以及列表、标题、引用、强调、表格等标记保持原样
+func (t *Transform) TransformMarkdown(inputs, method string) (string, error) {
+	output, _, err := t.transformMarkup(inputs, method, markdownSpans(inputs), false)
+	return output, err
+}
+
+// transformMarkup 按片段转换，只转换可见文本，其余字节原样输出；align 为 true 时返回输出与输入的对齐。
+// 未能转换的数字按整个输入中的位置报告
+func (t *Transform) transformMarkup(inputs, method string, spans []markupSpan, align bool) (string, Alignment, error) {
+	if method != "cn2an" && method != "an2cn" {
+		return "", nil, fmt.Errorf("error method: %s, only support 'cn2an' and 'an2cn'", method)
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(inputs))
+	var alignment Alignment
+	var failures []ConversionError
+	for _, span := range spans {
+		text := inputs[span.start:span.end]
+		dst := builder.Len()
+		if !span.text || strings.TrimSpace(text) == "" {
+			builder.WriteString(text)
+			if align {
+				alignment = appendSpan(alignment, AlignmentSpan{SrcStart: span.start, SrcEnd: span.end, DstStart: dst, DstEnd: dst + len(text)})
+			}
+			continue
+		}
+
+		var output string
+		var spanAlignment Alignment
+		var spanFailures []ConversionError
+		var err error
+		if align || t.reportsFailures() {
+			output, spanAlignment, spanFailures, err = t.transformWithFailures(text, method)
+		} else {
+			output, err = t.transform(text, method, nil)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		builder.WriteString(output)
+		if align {
+			for _, s := range spanAlignment {
+				s.SrcStart, s.SrcEnd = s.SrcStart+span.start, s.SrcEnd+span.start
+				s.DstStart, s.DstEnd = s.DstStart+dst, s.DstEnd+dst
+				alignment = appendSpan(alignment, s)
+			}
+		}
+		for _, failure := range spanFailures {
+			failure.Start, failure.End = failure.Start+span.start, failure.End+span.start
+			failures = append(failures, failure)
+		}
+	}
+	return builder.String(), alignment, t.reportFailures(failures)
+}
+
+// markupSpan 标记文本中的一段，start、end 为字节偏移，text 为 true 时是需要转换的可见文本
+type markupSpan struct {
+	start, end int
+	text       bool
+}
+
+// markupSpans 按顺序累积片段，相邻的同类片段合并
+type markupSpans []markupSpan
+
+// add 追加原样输出的片段
+func (b *markupSpans) add(start, end int) {
+	b.append(start, end, false)
+}
+
+// text 追加可见文本，其中的网址、邮箱和字符实体原样输出
+func (b *markupSpans) text(s string, start, end int) {
+	last := start
+	for _, loc := range markupVerbatimTextRe.FindAllStringIndex(s[start:end], -1) {
+		b.append(last, start+loc[0], true)
+		b.append(start+loc[0], start+loc[1], false)
+		last = start + loc[1]
+	}
+	b.append(last, end, true)
+}
+
+func (b *markupSpans) append(start, end int, text bool) {
+	if start >= end {
+		return
+	}
+	if n := len(*b); n > 0 && (*b)[n-1].text == text && (*b)[n-1].end == start {
+		(*b)[n-1].end = end
+		return
+	}
+	*b = append(*b, markupSpan{start: start, end: end, text: text})
+}
+
+var (
+	// markupVerbatimTextRe 文本中保持原样的网址、邮箱和字符实体；不带协议的 example.com/page-3 只取 ASCII 路径
+	markupVerbatimTextRe = regexp.MustCompile(`(?i)\b(?:https?|ftp)://[^\s<>"'，。、；）」】]+|\bwww\.[^\s<>"'，。、；）」】]+|[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}|` +
+		`\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}\b(?:/[^\s<>"'\x{80}-\x{10FFFF}]*)?|&(?:#[0-9]+|#x[0-9a-f]+|[a-z][a-z0-9]*);`)
+
+	// htmlRawTextElements 内容不解析标签的元素
+	htmlRawTextElements = map[string]bool{"script": true, "style": true}
+	// htmlVerbatimElements 内容保持原样的元素
+	htmlVerbatimElements = map[string]bool{"code": true, "pre": true, "kbd": true, "samp": true, "textarea": true}
+)
+
+// htmlSpans 将 HTML 切分为标签和文本
+func htmlSpans(s string) []markupSpan {
+	var b markupSpans
+	// verbatim 为当前所在的原样输出元素，depth 为其嵌套层数
+	verbatim, depth := "", 0
+	pos := 0
+	for pos < len(s) {
+		lt := strings.IndexByte(s[pos:], '<')
+		if lt == -1 {
+			lt = len(s) - pos
+		}
+		if depth > 0 {
+			b.add(pos, pos+lt)
+		} else {
+			b.text(s, pos, pos+lt)
+		}
+		pos += lt
+		if pos == len(s) {
+			break
+		}
+
+		end, name, closing, selfClosing := scanHTMLTag(s, pos)
+		if end == pos {
+			// 不是标签的 <
+			if depth > 0 {
+				b.add(pos, pos+1)
+			} else {
+				b.text(s, pos, pos+1)
+			}
+			pos++
+			continue
+		}
+		b.add(pos, end)
+		pos = end
+
+		switch {
+		case depth > 0:
+			if name == verbatim && closing {
+				depth--
+			} else if name == verbatim && !selfClosing {
+				depth++
+			}
+		case closing || selfClosing:
+		case htmlRawTextElements[name]:
+			i := indexFold(s[pos:], "</"+name)
+			if i == -1 {
+				i = len(s) - pos
+			}
+			b.add(pos, pos+i)
+			pos += i
+		case htmlVerbatimElements[name]:
+			verbatim, depth = name, 1
+		}
+	}
+	return b
+}
+
+// scanHTMLTag 识别 s[pos:] 开头的标签、注释或声明，返回结束位置和小写的元素名；不是标签时 end 为 pos
+func scanHTMLTag(s string, pos int) (end int, name string, closing, selfClosing bool) {
+	rest := s[pos:]
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		return pos + indexEnd(rest, "-->", 4), "", false, false
+	case strings.HasPrefix(rest, "<![CDATA["):
+		return pos + indexEnd(rest, "]]>", 9), "", false, false
+	case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+		return pos + indexEnd(rest, ">", 2), "", false, false
+	}
+
+	i := 1
+	if i < len(rest) && rest[i] == '/' {
+		closing = true
+		i++
+	}
+	nameStart := i
+	for i < len(rest) && (isASCIILetter(rest[i]) || i > nameStart && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '-' || rest[i] == ':')) {
+		i++
+	}
+	if i == nameStart {
+		return pos, "", false, false
+	}
+	name = strings.ToLower(rest[nameStart:i])
+
+	// 属性值中可能含有 >
+	var quote byte
+	for ; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return pos + i + 1, name, closing, rest[i-1] == '/'
+		}
+	}
+	return len(s), name, closing, false
+}
+
+// indexEnd 返回 s 中 from 之后第一个 sep 的结束位置，没有时返回 len(s)
+func indexEnd(s, sep string, from int) int {
+	if i := strings.Index(s[from:], sep); i != -1 {
+		return from + i + len(sep)
+	}
+	return len(s)
+}
+
+// indexFold 不区分 ASCII 大小写查找 sep
+func indexFold(s, sep string) int {
+	for i := 0; i+len(sep) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(sep)], sep) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+var (
+	// markdownFenceRe 围栏代码块的起止行
+	markdownFenceRe = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	// markdownLinePrefixRe 行首的引用、标题、列表及任务标记
+	markdownLinePrefixRe = regexp.MustCompile(`^[ \t]*(?:>[ \t]?)*[ \t]*(?:#{1,6}(?:[ \t]+|$)|(?:[-*+]|[0-9]{1,9}[.)])(?:[ \t]+|$)(?:\[[ xX]\][ \t]+)?)?`)
+	// markdownListItemRe 列表项
+	markdownListItemRe = regexp.MustCompile(`^[ \t]*(?:>[ \t]?)*[ \t]*(?:[-*+]|[0-9]{1,9}[.)])(?:[ \t]|$)`)
+	// markdownVerbatimLineRe 整行保持原样：链接引用定义（不含脚注）、分隔线及 Setext 标题下划线
+	markdownVerbatimLineRe = regexp.MustCompile(`^ {0,3}(?:\[[^\]^][^\]]*\]:|(?:[-*_=][ \t]*){3,}$)`)
+	// markdownTableDelimiterRe 表格的对齐行
+	markdownTableDelimiterRe = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+)
+
+// markdownSpans 将 Markdown 切分为标记和文本
+func markdownSpans(s string) []markupSpan {
+	var b markupSpans
+
+	// 按行切分，行尾包含换行符
+	var lines [][2]int
+	for start := 0; start < len(s); {
+		end := strings.IndexByte(s[start:], '\n')
+		if end == -1 {
+			end = len(s)
+		} else {
+			end += start + 1
+		}
+		lines = append(lines, [2]int{start, end})
+		start = end
+	}
+	lineText := func(i int) string {
+		return strings.TrimRight(s[lines[i][0]:lines[i][1]], "\r\n")
+	}
+	isBlank := func(i int) bool {
+		return strings.TrimSpace(lineText(i)) == ""
+	}
+
+	// 表格：对齐行及其前后相连的非空行
+	table := make([]bool, len(lines))
+	for i := range lines {
+		line := lineText(i)
+		if !strings.Contains(line, "|") || !markdownTableDelimiterRe.MatchString(line) || i == 0 || isBlank(i-1) {
+			continue
+		}
+		table[i-1] = true
+		for j := i; j < len(lines) && !isBlank(j); j++ {
+			table[j] = true
+		}
+	}
+
+	i := 0
+	// YAML front matter
+	if len(lines) > 0 && lineText(0) == "---" {
+		for j := 1; j < len(lines); j++ {
+			if line := lineText(j); line == "---" || line == "..." {
+				b.add(0, lines[j][1])
+				i = j + 1
+				break
+			}
+		}
+	}
+
+	var fence string
+	var inComment, inCode, inList, prevBlank bool
+	prevBlank = true
+	for ; i < len(lines); i++ {
+		start, end := lines[i][0], lines[i][1]
+		line := lineText(i)
+		blank := isBlank(i)
+
+		switch {
+		case fence != "":
+			// 围栏代码块内
+			b.add(start, end)
+			if m := markdownFenceRe.FindStringSubmatch(line); m != nil && m[1][0] == fence[0] && len(m[1]) >= len(fence) && strings.TrimSpace(line[len(m[0]):]) == "" {
+				fence = ""
+			}
+		case inComment:
+			b.add(start, end)
+			inComment = !strings.Contains(line, "-->")
+		case blank:
+			b.add(start, end)
+		case markdownFenceRe.MatchString(line):
+			b.add(start, end)
+			fence = markdownFenceRe.FindStringSubmatch(line)[1]
+		case (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")) && (inCode || prevBlank && !inList):
+			// 缩进代码块
+			b.add(start, end)
+			inCode = true
+		case markdownVerbatimLineRe.MatchString(line):
+			b.add(start, end)
+		default:
+			if markdownListItemRe.MatchString(line) {
+				inList = true
+			} else if line[0] != ' ' && line[0] != '\t' && prevBlank {
+				inList = false
+			}
+			if c := strings.Index(line, "<!--"); c != -1 && !strings.Contains(line[c:], "-->") {
+				inComment = true
+			}
+			prefix := len(markdownLinePrefixRe.FindString(line))
+			b.add(start, start+prefix)
+			markdownInlineSpans(&b, s, start+prefix, start+len(line), table[i])
+			b.add(start+len(line), end)
+		}
+		if !blank && fence == "" && !(inCode && (strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t"))) {
+			inCode = false
+		}
+		prevBlank = blank
+	}
+	return b
+}
+
+// markdownInlineSpans 切分一行中的行内标记和文本
+func markdownInlineSpans(b *markupSpans, s string, start, end int, table bool) {
+	textStart := start
+	verbatim := func(from, to int) {
+		b.text(s, textStart, from)
+		b.add(from, to)
+		textStart = to
+	}
+
+	for i := start; i < end; {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < end && isASCIIPunct(s[i+1]):
+			// 转义字符
+			verbatim(i, i+2)
+			i += 2
+		case c == '`':
+			// 行内代码：与起始等长的反引号结束
+			n := runLength(s, i, end, '`')
+			closeAt := -1
+			for j := i + n; j < end; {
+				if s[j] != '`' {
+					j++
+					continue
+				}
+				m := runLength(s, j, end, '`')
+				if m == n {
+					closeAt = j
+					break
+				}
+				j += m
+			}
+			if closeAt == -1 {
+				verbatim(i, i+n)
+				i += n
+				continue
+			}
+			verbatim(i, closeAt+n)
+			i = closeAt + n
+		case c == '<':
+			// 自动链接、内嵌 HTML 标签及注释
+			if to, _, _, _ := scanHTMLTag(s[:end], i); to > i {
+				verbatim(i, to)
+				i = to
+			} else if to := strings.IndexByte(s[i:end], '>'); to != -1 && strings.Contains(s[i:i+to], ":") && !strings.ContainsAny(s[i:i+to], " \t") {
+				verbatim(i, i+to+1)
+				i += to + 1
+			} else {
+				i++
+			}
+		case c == ']' && i+1 < end && (s[i+1] == '(' || s[i+1] == '['):
+			// 链接地址、引用标签
+			to := matchBracket(s, i+1, end)
+			if to == -1 {
+				i++
+				continue
+			}
+			verbatim(i, to)
+			i = to
+		case c == '[' && i+1 < end && s[i+1] == '^':
+			// 脚注
+			to := strings.IndexByte(s[i:end], ']')
+			if to == -1 {
+				i++
+				continue
+			}
+			verbatim(i, i+to+1)
+			i += to + 1
+		case c == '{' && i+1 < end && (s[i+1] == '#' || s[i+1] == '.'):
+			// 标题属性 {#id .class}
+			to := strings.IndexByte(s[i:end], '}')
+			if to == -1 {
+				i++
+				continue
+			}
+			verbatim(i, i+to+1)
+			i += to + 1
+		case c == '_' && i > start && isASCIIAlnum(s[i-1]) && i+runLength(s, i, end, c) < end && isASCIIAlnum(s[i+runLength(s, i, end, c)]):
+			// 单词内的 _ 不是强调标记（同 CommonMark），my_var_2 等标识符整体保持原样
+			from, to := i, i
+			for from > textStart && isASCIIWord(s[from-1]) {
+				from--
+			}
+			for to < end && isASCIIWord(s[to]) {
+				to++
+			}
+			verbatim(from, to)
+			i = to
+		case c == '*' || c == '_' || c == '~':
+			// 强调标记；数字之间的 * 视为乘号
+			n := runLength(s, i, end, c)
+			if c == '*' && i > start && isASCIIDigit(s[i-1]) && i+n < end && isASCIIDigit(s[i+n]) {
+				i += n
+				continue
+			}
+			verbatim(i, i+n)
+			i += n
+		case c == '|' && table:
+			verbatim(i, i+1)
+			i++
+		default:
+			i++
+		}
+	}
+	b.text(s, textStart, end)
+}
+
+// runLength 返回 s[i:end] 开头连续 c 的个数
+func runLength(s string, i, end int, c byte) int {
+	n := 0
+	for i+n < end && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// matchBracket 返回 s[open] 处的括号对应的右括号之后的位置，没有时返回 -1
+func matchBracket(s string, open, end int) int {
+	closing := byte(')')
+	if s[open] == '[' {
+		closing = ']'
+	}
+	depth := 0
+	for i := open; i < end; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case s[open]:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIIAlnum(c byte) bool {
+	return isASCIIDigit(c) || isASCIILetter(c)
+}
+
+func isASCIIWord(c byte) bool {
+	return isASCIIAlnum(c) || c == '_'
+}
+
+func isASCIIPunct(c byte) bool {
+	return c >= '!' && c <= '/' || c >= ':' && c <= '@' || c >= '[' && c <= '`' || c >= '{' && c <= '~'
+}
@@ -0,0 +1,55 @@
+package gocn2an
+
+import "testing"
+
+func TestTransformHTML(t *testing.T) {
+	testData := []struct {
+		input, method, expected string
+	}{
+		{`<h2 id="sec-3">第三章</h2>`, "cn2an", `<h2 id="sec-3">第3章</h2>`},
+		{`<p data-x='a>3'>共有十二节</p>`, "cn2an", `<p data-x='a>3'>共有12节</p>`},
+		{`<p>一百元&nbsp;，见 https://ex.com/a-1</p>`, "cn2an", `<p>100元&nbsp;，见 https://ex.com/a-1</p>`},
+		{`<pre><code>一百</code> 二百</pre> 三百`, "cn2an", `<pre><code>一百</code> 二百</pre> 300`},
+		{`<script>var a = 1 < 2; // 一百</script><!-- 一百 -->`, "cn2an", `<script>var a = 1 < 2; // 一百</script><!-- 一百 -->`},
+		{`<p title="3-2">3-2=1，&#12;</p>`, "an2cn", `<p title="3-2">三减二等于一，&#12;</p>`},
+		{`<a href="/p/2">第2页</a><kbd>Ctrl+1</kbd>`, "an2cn", `<a href="/p/2">第二页</a><kbd>Ctrl+1</kbd>`},
+		{`没有标签的12`, "an2cn", `没有标签的十二`},
+		{`<p>访问example.com/page-3获取第2页</p>`, "an2cn", `<p>访问example.com/page-3获取第二页</p>`},
+	}
+
+	transform := NewTransform()
+	for _, item := range testData {
+		if got, err := transform.TransformHTML(item.input, item.method); err != nil || got != item.expected {
+			t.Errorf("TransformHTML(%q, %s) = %q, %v, want %q", item.input, item.method, got, err, item.expected)
+		}
+	}
+	if _, err := transform.TransformHTML("<p>1</p>", "abc"); err == nil {
+		t.Error("TransformHTML with invalid method should return error")
+	}
+}
+
+func TestTransformMarkdown(t *testing.T) {
+	testData := []struct {
+		input, method, expected string
+	}{
+		{"# 第三章 {#sec-3}\n", "cn2an", "# 第3章 {#sec-3}\n"},
+		{"**五十**个 `x-1`", "cn2an", "**50**个 `x-1`"},
+		{"[第二节](https://a.com/2-3 \"t 3\") 见[^1]\n\n[^1]: 注释二十", "cn2an", "[第2节](https://a.com/2-3 \"t 3\") 见[^1]\n\n[^1]: 注释20"},
+		{"[一百][1]\n\n[1]: http://x.com/3\n", "cn2an", "[100][1]\n\n[1]: http://x.com/3\n"},
+		{"```go\nx := 一百\n```\n二百", "cn2an", "```go\nx := 一百\n```\n200"},
+		{"段落\n\n    code 一百\n\n正文一百", "cn2an", "段落\n\n    code 一百\n\n正文100"},
+		{"---\ntitle: 第一\n---\n第二", "cn2an", "---\ntitle: 第一\n---\n第2"},
+		{"1. 3-2=1\n- 共 12 个 *强调* 3*4", "an2cn", "1. 三减二等于一\n- 共 十二 个 *强调* 三乘四"},
+		{"> 5 > 3\n+ <span id=\"a1\">5</span> <https://x.com/1>", "an2cn", "> 五 大于 三\n+ <span id=\"a1\">五</span> <https://x.com/1>"},
+		{"| a | b |\n|---|---:|\n| 1 | 2 |\n", "an2cn", "| a | b |\n|---|---:|\n| 一 | 二 |\n"},
+		{"\\*5\\*", "an2cn", "\\*五\\*"},
+		{"见 example.com/page-3，变量 my_var_2 共 _3_ 个", "an2cn", "见 example.com/page-3，变量 my_var_2 共 _三_ 个"},
+	}
+
+	transform := NewTransform()
+	for _, item := range testData {
+		if got, err := transform.TransformMarkdown(item.input, item.method); err != nil || got != item.expected {
+			t.Errorf("TransformMarkdown(%q, %s) = %q, %v, want %q", item.input, item.method, got, err, item.expected)
+		}
+	}
+}
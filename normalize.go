@@ -1,18 +1,37 @@
 package gocn2an
 
-import "strings"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
 
 var normalizeRuneMap = map[rune]rune{
 	'兩': '两',
 	'貳': '贰',
+	'貮': '贰',
 	'參': '叁',
+	'叄': '叁',
 	'陸': '陆',
 	'億': '亿',
 	'萬': '万',
 	'點': '点',
 	'圓': '圆',
 	'負': '负',
-	'廿': '廿', // handled separately where needed
+	'塊': '块',
+	'數': '数',
+	'○': '〇',
+	'◯': '〇',
+	// CJK compatibility ideographs
+	'\uF96B': '叁', // 參
+	'\uF973': '拾',
+	'\uF978': '两', // 兩
+	'\uF9B2': '零',
+	'\uF9D1': '六',
+	'\uF9D3': '陆', // 陸
+	'卄':      '廿',
+	'丗':      '卅',
 }
 
 // normalizeText performs common preprocessing:
@@ -38,3 +57,225 @@ func normalizeText(s string) string {
 	}
 	return result.String()
 }
+
+// variantRunesOf returns the keys of normalizeRuneMap that normalize to one
+// of the runes in targets.
+func variantRunesOf(targets string) string {
+	var builder strings.Builder
+	for from, to := range normalizeRuneMap {
+		if from != to && strings.ContainsRune(targets, to) {
+			builder.WriteRune(from)
+		}
+	}
+	return builder.String()
+}
+
+// numericFormRange describes a contiguous block of numeral glyphs.
+type numericFormRange struct {
+	lo, hi     rune
+	start      int  // value of lo
+	positional bool // place-value digit (Suzhou, counting rods) rather than a whole value (⑳)
+}
+
+// numericFormRanges lists the numeral glyphs folded by foldNumericForms.
+var numericFormRanges = []numericFormRange{
+	{0x2460, 0x2473, 1, false},    // ①-⑳
+	{0x2474, 0x2487, 1, false},    // ⑴-⒇
+	{0x24EA, 0x24EA, 0, false},    // ⓪
+	{0x24EB, 0x24F4, 11, false},   // ⓫-⓴
+	{0x24F5, 0x24FE, 1, false},    // ⓵-⓾
+	{0x24FF, 0x24FF, 0, false},    // ⓿
+	{0x2776, 0x277F, 1, false},    // ❶-❿
+	{0x2780, 0x2789, 1, false},    // ➀-➉
+	{0x278A, 0x2793, 1, false},    // ➊-➓
+	{0x3220, 0x3229, 1, false},    // ㈠-㈩
+	{0x3251, 0x325F, 21, false},   // ㉑-㉟
+	{0x3280, 0x3289, 1, false},    // ㊀-㊉
+	{0x32B1, 0x32BF, 36, false},   // ㊱-㊿
+	{0x3021, 0x3029, 1, true},     // 〡-〩 Suzhou numerals
+	{0x3038, 0x3038, 10, false},   // 〸
+	{0x3039, 0x3039, 20, false},   // 〹
+	{0x303A, 0x303A, 30, false},   // 〺
+	{0x1D360, 0x1D368, 1, true},   // counting rod units
+	{0x1D369, 0x1D371, 1, true},   // counting rod tens
+	{0x1F51F, 0x1F51F, 10, false}, // 🔟
+}
+
+// romanNumeralValue maps Roman numeral letters to their values.
+var romanNumeralValue = map[rune]int{
+	'Ⅰ': 1, 'Ⅱ': 2, 'Ⅲ': 3, 'Ⅳ': 4, 'Ⅴ': 5, 'Ⅵ': 6,
+	'Ⅶ': 7, 'Ⅷ': 8, 'Ⅸ': 9, 'Ⅹ': 10, 'Ⅺ': 11, 'Ⅻ': 12,
+	'Ⅼ': 50, 'Ⅽ': 100, 'Ⅾ': 500, 'Ⅿ': 1000,
+	'ⅰ': 1, 'ⅱ': 2, 'ⅲ': 3, 'ⅳ': 4, 'ⅴ': 5, 'ⅵ': 6,
+	'ⅶ': 7, 'ⅷ': 8, 'ⅸ': 9, 'ⅹ': 10, 'ⅺ': 11, 'ⅻ': 12,
+	'ⅼ': 50, 'ⅽ': 100, 'ⅾ': 500, 'ⅿ': 1000,
+}
+
+// lookupNumericForm returns the value of r and whether it is a place-value digit.
+func lookupNumericForm(r rune) (int, bool, bool) {
+	for _, rg := range numericFormRanges {
+		if r >= rg.lo && r <= rg.hi {
+			return rg.start + int(r-rg.lo), rg.positional, true
+		}
+	}
+	return 0, false, false
+}
+
+// decimalDigitValue returns the value of a unicode.Nd digit. Unicode encodes
+// Nd digits in contiguous 0-9 runs, so counting the Nd runes before r is enough.
+func decimalDigitValue(r rune) int {
+	n := 0
+	for unicode.Is(unicode.Nd, r-rune(n)-1) {
+		n++
+	}
+	return n % 10
+}
+
+// foldNumericForms rewrites alternative numeral glyphs:
+//  1. unicode.Nd digits (Arabic-Indic, Devanagari, ...) and keycap emoji -> ASCII digits
+//  2. circled/parenthesized numbers, Roman numerals, Suzhou numerals and
+//     counting rods -> Chinese numerals when toChinese is set, ASCII digits otherwise
+func foldNumericForms(s string, toChinese bool) string {
+	folded, _ := foldNumericFormsWithOffsets(s, toChinese, false)
+	return folded
+}
+
+// foldNumericFormsWithOffsets is foldNumericForms that also returns, for every
+// byte of the result plus one trailing entry, the byte offset in s it came from.
+// In a sentence, Roman numerals are folded only in numeric context (see romanNumeralInContext),
+// so Ⅹ射线 and iPhone Ⅹ stay as they are.
+func foldNumericFormsWithOffsets(s string, toChinese, sentence bool) (string, []int) {
+	runes := []rune(s)
+	starts := make([]int, 0, len(runes)+1)
+	for i := range s {
+		starts = append(starts, i)
+	}
+	starts = append(starts, len(s))
+
+	var builder strings.Builder
+	offsets := make([]int, 0, len(s)+1)
+	from := 0
+	for i := 0; i < len(runes); i++ {
+		// Bytes written in the previous iteration come from the rune it started at
+		for len(offsets) < builder.Len() {
+			offsets = append(offsets, from)
+		}
+		from = starts[i]
+		r := runes[i]
+
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+			// Keycap emoji: 1️⃣
+			j := i + 1
+			if j < len(runes) && runes[j] == 0xFE0F {
+				j++
+			}
+			if j < len(runes) && runes[j] == 0x20E3 {
+				i = j
+			}
+			continue
+		}
+
+		if r > unicode.MaxASCII && unicode.Is(unicode.Nd, r) {
+			builder.WriteByte(byte('0' + decimalDigitValue(r)))
+			continue
+		}
+
+		if _, ok := romanNumeralValue[r]; ok {
+			j := i
+			for j < len(runes) {
+				if _, ok := romanNumeralValue[runes[j]]; !ok {
+					break
+				}
+				j++
+			}
+			if sentence && !romanNumeralInContext(runes, i, j) {
+				builder.WriteString(string(runes[i:j]))
+			} else {
+				builder.WriteString(formatFoldedValue(romanValue(runes[i:j]), toChinese))
+			}
+			i = j - 1
+			continue
+		}
+
+		// 〇 is the zero of Suzhou numerals and counting rods
+		if r == '〇' && !toChinese && nearPositionalForm(runes, i) {
+			builder.WriteByte('0')
+			continue
+		}
+
+		if val, positional, ok := lookupNumericForm(r); ok {
+			if positional && toChinese {
+				builder.WriteString(NumberLowAN2CN[val])
+			} else if positional {
+				builder.WriteByte(byte('0' + val))
+			} else {
+				builder.WriteString(formatFoldedValue(val, toChinese))
+			}
+			continue
+		}
+
+		builder.WriteRune(r)
+	}
+	for len(offsets) < builder.Len() {
+		offsets = append(offsets, from)
+	}
+	return builder.String(), append(offsets, len(s))
+}
+
+// nearPositionalForm reports whether the 〇 at idx belongs to a run of place-value glyphs.
+func nearPositionalForm(runes []rune, idx int) bool {
+	for _, step := range []int{-1, 1} {
+		for i := idx + step; i >= 0 && i < len(runes); i += step {
+			if runes[i] == '〇' {
+				continue
+			}
+			_, positional, ok := lookupNumericForm(runes[i])
+			if ok && positional {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// romanSuffixRe matches words that mark a Roman numeral in a sentence as a number: Ⅻ号, 第Ⅲ章, Ⅱ型.
+var romanSuffixRe = regexp.MustCompile(`^(?:号|號|期|卷|章|节|節|集|册|冊|届|屆|代|世|级|級|部|版|型|[十百千万亿萬億])`)
+
+// romanNumeralInContext reports whether the Roman numeral runes[start:end] follows 第
+// or is followed by a measure word or one of romanSuffixRe.
+func romanNumeralInContext(runes []rune, start, end int) bool {
+	if start > 0 && runes[start-1] == '第' {
+		return true
+	}
+	rest := string(runes[end:min(end+4, len(runes))])
+	return romanSuffixRe.MatchString(rest) || measureWordPrefixRe.MatchString(rest)
+}
+
+// romanValue evaluates a run of Roman numeral letters.
+func romanValue(runes []rune) int {
+	total := 0
+	for i, r := range runes {
+		v := romanNumeralValue[r]
+		if i+1 < len(runes) && v < romanNumeralValue[runes[i+1]] {
+			total -= v
+		} else {
+			total += v
+		}
+	}
+	return total
+}
+
+// formatFoldedValue renders a whole value as ASCII digits or Chinese numerals.
+func formatFoldedValue(val int, toChinese bool) string {
+	str := strconv.Itoa(val)
+	if !toChinese {
+		return str
+	}
+	cn, err := (&An2Cn{}).integerConvert(str, "low")
+	if err != nil {
+		return str
+	}
+	return cn
+}
@@ -0,0 +1,95 @@
+package gocn2an
+
+import (
+	"testing"
+)
+
+func TestFoldNumericForms(t *testing.T) {
+	testData := map[string][2]string{
+		"〡〢〣":   {"123", "一二三"},
+		"〹":     {"20", "二十"},
+		"𝍩𝍢":    {"13", "一三"},
+		"①":     {"1", "一"},
+		"⑳":     {"20", "二十"},
+		"⑴":     {"1", "一"},
+		"㈢":     {"3", "三"},
+		"㊉":     {"10", "十"},
+		"Ⅻ":     {"12", "十二"},
+		"ⅩⅣ":    {"14", "十四"},
+		"1️⃣2⃣": {"12", "12"},
+		"🔟":     {"10", "十"},
+		"١٢٣":   {"123", "123"},
+		"१२३":   {"123", "123"},
+		"第3章":   {"第3章", "第3章"},
+		"❤️":    {"❤️", "❤️"},
+	}
+
+	for input, expected := range testData {
+		if got := foldNumericForms(input, false); got != expected[0] {
+			t.Errorf("foldNumericForms(%q, false) = %q, want %q", input, got, expected[0])
+		}
+		if got := foldNumericForms(input, true); got != expected[1] {
+			t.Errorf("foldNumericForms(%q, true) = %q, want %q", input, got, expected[1])
+		}
+	}
+}
+
+func TestNumericFormsAccepted(t *testing.T) {
+	c := NewCn2An()
+	cn2anData := map[string][2]interface{}{
+		"⑳":    {"strict", 20.0},
+		"〡〢〣":  {"normal", 123.0},
+		"٣百":   {"smart", 300.0},
+		"Ⅻ万":   {"strict", 120000.0},
+		"3️⃣千": {"smart", 3000.0},
+	}
+	for input, expected := range cn2anData {
+		mode := expected[0].(string)
+		result, err := c.Cn2an(input, mode)
+		if err != nil {
+			t.Errorf("Cn2an(%q, %s) error: %v", input, mode, err)
+			continue
+		}
+		if result != expected[1].(float64) {
+			t.Errorf("Cn2an(%q, %s) = %f, want %f", input, mode, result, expected[1])
+		}
+	}
+
+	a := NewAn2Cn()
+	an2cnData := map[string]string{
+		"١٢٣": "一百二十三",
+		"⑳":   "二十",
+		"〡〇":  "十",
+		"Ⅻ":   "十二",
+	}
+	for input, expected := range an2cnData {
+		result, err := a.An2cn(input, "low")
+		if err != nil {
+			t.Errorf("An2cn(%q, low) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("An2cn(%q, low) = %s, want %s", input, result, expected)
+		}
+	}
+
+	tr := NewTransform()
+	transformData := map[string][2]string{
+		"第Ⅲ章共١٢٣页": {"an2cn", "第三章共一百二十三页"},
+		"步骤③完成":    {"an2cn", "步骤三完成"},
+		"〡〢〣块":     {"cn2an", "123块"},
+		"Ⅹ射线检查":    {"cn2an", "Ⅹ射线检查"},
+		"iPhone Ⅹ": {"cn2an", "iPhone Ⅹ"},
+		"Ⅱ型糖尿病":    {"cn2an", "2型糖尿病"},
+	}
+	for input, expected := range transformData {
+		result, err := tr.Transform(input, expected[0])
+		if err != nil {
+			t.Errorf("Transform(%q, %s) error: %v", input, expected[0], err)
+			continue
+		}
+		if result != expected[1] {
+			t.Errorf("Transform(%q, %s) = %s, want %s", input, expected[0], result, expected[1])
+		}
+	}
+}
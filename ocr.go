@@ -0,0 +1,223 @@
+package gocn2an
+
+import (
+	"strings"
+	"unicode"
+)
+
+// OCRSubstitution 一次 OCR 字形修正记录
+type OCRSubstitution struct {
+	Position int    // 修正位置（按字符计，对应原始输入）
+	Original string // 原始字形
+	Replaced string // 修正后的字形，为空表示删除
+	Reason   string // 修正原因
+}
+
+// ocrZeroLike 容易与零混淆的字形
+var ocrZeroLike = map[rune]bool{'O': true, 'o': true, '0': true, '〇': true, '零': true}
+
+// ocrOneLike 容易与 1/一 混淆的字形
+var ocrOneLike = map[rune]bool{'l': true, 'I': true, '|': true, '丨': true, 'ǀ': true}
+
+// ocrDashLike 容易与「一」混淆的横线
+var ocrDashLike = map[rune]bool{'—': true, '–': true, '─': true, '━': true, 'ー': true}
+
+// ocrTwoLike 容易与「二」混淆的字形
+var ocrTwoLike = map[rune]bool{'=': true, 'ニ': true, '〓': true}
+
+// ocrContext 易混字形所处的上下文
+type ocrContext int
+
+const (
+	ocrContextNone ocrContext = iota
+	ocrContextChinese
+	ocrContextArabic
+)
+
+// NormalizeOCR 修正 OCR 识别产生的易混字形，在 normalizeText 的基础上根据相邻的
+// 数字、单位判断上下文，如 壹万〇O元 => 壹万元、—百 => 一百、=十 => 二十、l0元 => 10元，
+// 并返回每一处修正，便于人工复核
+func NormalizeOCR(s string) (string, []OCRSubstitution) {
+	runes := []rune(normalizeText(s))
+	output := make([]rune, len(runes))
+	copy(output, runes)
+	var subs []OCRSubstitution
+
+	record := func(pos int, replaced string, reason string) {
+		subs = append(subs, OCRSubstitution{
+			Position: pos,
+			Original: string(runes[pos]),
+			Replaced: replaced,
+			Reason:   reason,
+		})
+	}
+
+	for i, r := range runes {
+		if !isOCRConfusable(r) {
+			continue
+		}
+		ctx, between := detectOCRContext(runes, i)
+		var fixed rune
+		reason := ""
+		switch ctx {
+		case ocrContextChinese:
+			switch {
+			case ocrZeroLike[r] && r != '〇' && r != '零':
+				fixed, reason = '零', "中文数字中的零"
+			case ocrOneLike[r]:
+				fixed, reason = '一', "中文数字中的一"
+			case ocrDashLike[r] && !between:
+				// 三—五天 中的横线为范围
+				fixed, reason = '一', "中文数字中的一"
+			case ocrTwoLike[r] && !between:
+				// 一加一=二 中的等号为算式
+				fixed, reason = '二', "中文数字中的二"
+			}
+		case ocrContextArabic:
+			switch {
+			case ocrZeroLike[r] && r != '0':
+				fixed, reason = '0', "阿拉伯数字中的 0"
+			case ocrOneLike[r]:
+				fixed, reason = '1', "阿拉伯数字中的 1"
+			}
+		}
+		if reason != "" {
+			output[i] = fixed
+			record(i, string(fixed), reason)
+		}
+	}
+
+	// 金额中单位之后的零：连续的零只保留一个，位于元之前或末尾的零删除
+	var builder strings.Builder
+	for i := 0; i < len(output); i++ {
+		r := output[i]
+		if !isCNZeroRune(r) || i == 0 || !isCNUnitRune(output[i-1]) {
+			builder.WriteRune(r)
+			continue
+		}
+		next := nextNonZero(output, i)
+		end := next
+		if end == -1 {
+			end = len(output)
+		}
+		keep := next != -1 && !strings.ContainsRune("元圆块", output[next])
+		for j := i; j < end; j++ {
+			if keep && j == i {
+				builder.WriteRune(r)
+				continue
+			}
+			if keep {
+				record(j, "", "连续的零")
+			} else {
+				record(j, "", "单位后多余的零")
+			}
+		}
+		i = end - 1
+	}
+
+	return builder.String(), subs
+}
+
+// Cn2anOCR 先用 NormalizeOCR 修正易混字形，再按 mode 转换
+func (c *Cn2An) Cn2anOCR(inputs string, mode string) (float64, []OCRSubstitution, error) {
+	normalized, subs := NormalizeOCR(inputs)
+	output, err := c.Cn2an(normalized, mode)
+	return output, subs, err
+}
+
+// isOCRConfusable 判断是否为易混字形
+func isOCRConfusable(r rune) bool {
+	return ocrZeroLike[r] || ocrOneLike[r] || ocrDashLike[r] || ocrTwoLike[r]
+}
+
+// detectOCRContext 跳过相邻的易混字形，根据两侧最近的字符判断上下文：两侧均为同类数字，
+// 或一侧为数字、单位且后面的数字之后接单位、量词时才视为数字；与普通英文字母相连时视为单词，不做修正。
+// between 为 true 时两侧都是数字（不含单位），如 三—五、一=二 中的横线、等号不是数字
+func detectOCRContext(runes []rune, idx int) (ctx ocrContext, between bool) {
+	var sides [2]ocrContext
+	var digits, numeric [2]bool
+	for side, step := range []int{-1, 1} {
+		for i := idx + step; i >= 0 && i < len(runes); i += step {
+			r := runes[i]
+			if isOCRConfusable(r) && !isChineseAmountRune(r) && !(r >= '0' && r <= '9') {
+				continue
+			}
+			switch {
+			case isChineseAmountRune(r):
+				sides[side] = ocrContextChinese
+				digits[side] = isChineseDigitRune(r)
+				numeric[side] = digits[side] || isCNUnitRune(r)
+			case r >= '0' && r <= '9' || r == '.':
+				sides[side] = ocrContextArabic
+				digits[side] = r != '.'
+				numeric[side] = digits[side]
+			case r < unicode.MaxASCII && unicode.IsLetter(r):
+				return ocrContextNone, false
+			}
+			break
+		}
+	}
+
+	between = digits[0] && digits[1]
+	if sides[0] != ocrContextNone && sides[0] == sides[1] {
+		return sides[0], between
+	}
+	if unitAfterOCRNumber(runes, idx) {
+		for _, side := range []int{1, 0} {
+			if numeric[side] {
+				return sides[side], between
+			}
+		}
+	}
+	return ocrContextNone, between
+}
+
+// unitAfterOCRNumber 判断 idx 之后的数字和易混字形之后是否为单位或量词，如 —百、l0元、2l年
+func unitAfterOCRNumber(runes []rune, idx int) bool {
+	i := idx + 1
+	for i < len(runes) && (isOCRConfusable(runes[i]) || isChineseDigitRune(runes[i]) || runes[i] >= '0' && runes[i] <= '9') {
+		i++
+	}
+	return i < len(runes) && (isCNUnitRune(runes[i]) || measureWordPrefixRe.MatchString(string(runes[i:])))
+}
+
+// isChineseAmountRune 判断是否为中文数字、单位或金额用字
+func isChineseAmountRune(r rune) bool {
+	if _, ok := NumberCN2AN[r]; ok && r != '〇' && r != '零' {
+		return true
+	}
+	if isCNUnitRune(r) {
+		return true
+	}
+	if _, ok := ContractionCN2AN[r]; ok {
+		return true
+	}
+	return strings.ContainsRune("点负元圆块角分整正", r)
+}
+
+// isChineseDigitRune 判断是否为一至九等中文数字，不含零和单位
+func isChineseDigitRune(r rune) bool {
+	_, ok := NumberCN2AN[r]
+	return ok && !isCNZeroRune(r)
+}
+
+// isCNUnitRune 判断是否为中文数字单位
+func isCNUnitRune(r rune) bool {
+	_, ok := UnitCN2AN[r]
+	return ok
+}
+
+// isCNZeroRune 判断是否为中文的零
+func isCNZeroRune(r rune) bool {
+	return r == '零' || r == '〇'
+}
+
+// nextNonZero 返回 idx 之后第一个不是零的位置
+func nextNonZero(runes []rune, idx int) int {
+	for i := idx + 1; i < len(runes); i++ {
+		if !isCNZeroRune(runes[i]) {
+			return i
+		}
+	}
+	return -1
+}
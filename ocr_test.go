@@ -0,0 +1,63 @@
+package gocn2an
+
+import (
+	"testing"
+)
+
+func TestNormalizeOCR(t *testing.T) {
+	testData := map[string]string{
+		"壹万〇O元":   "壹万元",
+		"—百二十":    "一百二十",
+		"壹万—千":    "壹万一千",
+		"=十五":     "二十五",
+		"l0元":     "10元",
+		"1O0":     "100",
+		"2O2l年":   "2021年",
+		"一千O五":    "一千零五",
+		"一零零":     "一零零",
+		"Hello 2": "Hello 2",
+		"ID100":   "ID100",
+		"a=b":     "a=b",
+		"一加一=二":   "一加一=二",
+		"三—五天":    "三—五天",
+		"第一—第三":   "第一—第三",
+	}
+
+	for input, expected := range testData {
+		got, _ := NormalizeOCR(input)
+		if got != expected {
+			t.Errorf("NormalizeOCR(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestNormalizeOCRSubstitutions(t *testing.T) {
+	_, subs := NormalizeOCR("壹万〇O元")
+	expected := []OCRSubstitution{
+		{Position: 3, Original: "O", Replaced: "零", Reason: "中文数字中的零"},
+		{Position: 2, Original: "〇", Replaced: "", Reason: "单位后多余的零"},
+		{Position: 3, Original: "O", Replaced: "", Reason: "单位后多余的零"},
+	}
+	if len(subs) != len(expected) {
+		t.Fatalf("NormalizeOCR substitutions = %v, want %v", subs, expected)
+	}
+	for i := range expected {
+		if subs[i] != expected[i] {
+			t.Errorf("substitution[%d] = %+v, want %+v", i, subs[i], expected[i])
+		}
+	}
+}
+
+func TestCn2anOCR(t *testing.T) {
+	c := NewCn2An()
+	result, subs, err := c.Cn2anOCR("壹万—千〇O伍元", "normal")
+	if err != nil {
+		t.Fatalf("Cn2anOCR error: %v", err)
+	}
+	if result != 11005 {
+		t.Errorf("Cn2anOCR = %f, want %f", result, 11005.0)
+	}
+	if len(subs) == 0 {
+		t.Errorf("Cn2anOCR should report substitutions")
+	}
+}
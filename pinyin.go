@@ -0,0 +1,305 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// pinyinStyleList 拼音输出风格：tone(声调符号), number(数字标调), none(无声调)
+var pinyinStyleList = []string{"tone", "number", "none"}
+
+// pinyinToneMarks 元音的声调符号（一至四声）
+var pinyinToneMarks = map[rune][4]rune{
+	'a': {'ā', 'á', 'ǎ', 'à'},
+	'e': {'ē', 'é', 'ě', 'è'},
+	'i': {'ī', 'í', 'ǐ', 'ì'},
+	'o': {'ō', 'ó', 'ǒ', 'ò'},
+	'u': {'ū', 'ú', 'ǔ', 'ù'},
+	'ü': {'ǖ', 'ǘ', 'ǚ', 'ǜ'},
+}
+
+// pinyinSyllableCN 拼音音节到中文数字用字的映射，yi 的 一/亿 歧义在解析时处理
+var pinyinSyllableCN = map[string]rune{
+	"ling":  '零',
+	"yao":   '幺',
+	"yi":    '一',
+	"er":    '二',
+	"liang": '两',
+	"san":   '三',
+	"si":    '四',
+	"wu":    '五',
+	"liu":   '六',
+	"qi":    '七',
+	"ba":    '八',
+	"jiu":   '九',
+	"shi":   '十',
+	"bai":   '百',
+	"qian":  '千',
+	"wan":   '万',
+	"dian":  '点',
+	"fu":    '负',
+}
+
+// An2pinyin 阿拉伯数字转拼音，如 123 => yì bǎi èr shí sān
+// 在 low 模式的基础上处理「一」的变调，并在千、万、亿及开头的百之前使用「两」
+// style: tone(声调符号), number(数字标调), none(无声调)
+func (a *An2Cn) An2pinyin(inputs interface{}, style string) (string, error) {
+	if !contains(pinyinStyleList, style) {
+		return "", fmt.Errorf("style 仅支持 %v", pinyinStyleList)
+	}
+
+	output, err := a.An2cn(inputs, "low")
+	if err != nil {
+		return "", err
+	}
+
+	return CnToPinyin(useLiang(output), style)
+}
+
+// useLiang 「二」作为一节的首位且位于千、万、亿之前，或位于开头的百之前时读作「两」
+func useLiang(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if r != '二' || i+1 >= len(runes) {
+			continue
+		}
+		atStart := i == 0 || runes[i-1] == '负'
+		sectionStart := atStart || runes[i-1] == '零' || runes[i-1] == '万' || runes[i-1] == '亿'
+		switch runes[i+1] {
+		case '千', '万', '亿':
+			if sectionStart {
+				runes[i] = '两'
+			}
+		case '百':
+			if atStart {
+				runes[i] = '两'
+			}
+		}
+	}
+	return string(runes)
+}
+
+// CnToPinyin 中文数字（可带常用量词）转拼音，处理「一」的变调：
+// 一百 => yì bǎi，一个 => yí gè，单独或按位读时保持 yī
+func CnToPinyin(inputs string, style string) (string, error) {
+	if !contains(pinyinStyleList, style) {
+		return "", fmt.Errorf("style 仅支持 %v", pinyinStyleList)
+	}
+	if inputs == "" {
+		return "", errors.New("输入数据为空")
+	}
+
+	runes := []rune(normalizeText(inputs))
+	syllables := make([]string, 0, len(runes))
+	for i, r := range runes {
+		if num, ok := NumberCN2AN[r]; ok && r != '两' && r != '幺' && r != '〇' {
+			r = []rune(NumberLowAN2CN[num])[0]
+		} else if unit, ok := UnitCN2AN[r]; ok {
+			r = []rune(UnitLowAN2CN[unit])[0]
+		}
+
+		syllable, ok := PinyinCN[r]
+		if !ok {
+			syllable, ok = PinyinMeasureWordCN[r]
+		}
+		if !ok {
+			return "", fmt.Errorf("%c 不在转化范围内", r)
+		}
+
+		if r == '一' && i+1 < len(runes) {
+			if tone, ok := sandhiNextTone(runes, i+1); ok {
+				if tone == '4' {
+					syllable = "yi2"
+				} else {
+					syllable = "yi4"
+				}
+			}
+		}
+		syllables = append(syllables, renderPinyin(syllable, style))
+	}
+
+	return strings.Join(syllables, " "), nil
+}
+
+// sandhiNextTone 返回「一」之后的单位或量词的声调；后面是数字或小数点时不变调
+func sandhiNextTone(runes []rune, idx int) (byte, bool) {
+	r := runes[idx]
+	if unit, ok := UnitCN2AN[r]; ok {
+		syllable := PinyinCN[[]rune(UnitLowAN2CN[unit])[0]]
+		return syllable[len(syllable)-1], true
+	}
+	if r == '点' {
+		// 一点五 读 yī diǎn，一点（钟）读 yì diǎn
+		if idx+1 < len(runes) {
+			if _, isNum := NumberCN2AN[runes[idx+1]]; isNum {
+				return 0, false
+			}
+		}
+		return '3', true
+	}
+	if syllable, ok := PinyinMeasureWordCN[r]; ok {
+		return syllable[len(syllable)-1], true
+	}
+	return 0, false
+}
+
+// renderPinyin 按风格输出数字标调的音节
+func renderPinyin(syllable string, style string) string {
+	base, tone := syllable[:len(syllable)-1], int(syllable[len(syllable)-1]-'0')
+	switch style {
+	case "number":
+		return syllable
+	case "none":
+		return base
+	}
+
+	runes := []rune(base)
+	idx := -1
+	for i, r := range runes {
+		if r == 'a' || r == 'e' {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		if i := strings.Index(base, "ou"); i != -1 {
+			idx = len([]rune(base[:i]))
+		}
+	}
+	if idx == -1 {
+		for i, r := range runes {
+			if _, ok := pinyinToneMarks[r]; ok {
+				idx = i
+			}
+		}
+	}
+	if idx == -1 || tone < 1 || tone > 4 {
+		return base
+	}
+	runes[idx] = pinyinToneMarks[runes[idx]][tone-1]
+	return string(runes)
+}
+
+// Pinyin2an 拼音数字转阿拉伯数字，如 "yi bai er shi san"、"yībǎi èr shí sān"、
+// "yi1bai3" 或 "yibaiershisan" => 123，解析为中文数字后交由 Cn2an 按 mode 转换
+func (c *Cn2An) Pinyin2an(inputs string, mode string) (float64, error) {
+	cn, err := PinyinToCn(inputs)
+	if err != nil {
+		return 0, err
+	}
+	return c.Cn2an(cn, mode)
+}
+
+// PinyinToCn 将拼音数字转为中文数字
+func PinyinToCn(inputs string) (string, error) {
+	plain := stripPinyinTones(inputs)
+	if plain == "" {
+		return "", errors.New("输入数据为空")
+	}
+
+	var tokens []string
+	for _, field := range strings.Fields(plain) {
+		fieldTokens, ok := splitPinyinSyllables(field)
+		if !ok {
+			return "", fmt.Errorf("无法识别的拼音：%s", field)
+		}
+		tokens = append(tokens, fieldTokens...)
+	}
+
+	hasUnit := false
+	for _, token := range tokens {
+		if isCNUnitRune(pinyinSyllableCN[token]) {
+			hasUnit = true
+			break
+		}
+	}
+
+	var builder strings.Builder
+	for i, token := range tokens {
+		r := pinyinSyllableCN[token]
+		if token == "yi" && isPinyinYi(tokens, i, hasUnit) {
+			r = '亿'
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String(), nil
+}
+
+// isPinyinYi 判断 yi 是否表示「亿」：前面是数字（且整体带单位），或前面是单位且后面紧跟「数字+单位」
+func isPinyinYi(tokens []string, idx int, hasUnit bool) bool {
+	if idx == 0 {
+		return false
+	}
+	prev := pinyinSyllableCN[tokens[idx-1]]
+	if tokens[idx-1] != "yi" && !isCNUnitRune(prev) && prev != '点' && prev != '负' {
+		_, isNum := NumberCN2AN[prev]
+		return isNum && prev != '零' && hasUnit
+	}
+	if isCNUnitRune(prev) && prev != '万' && idx+2 < len(tokens) {
+		_, nextIsNum := NumberCN2AN[pinyinSyllableCN[tokens[idx+1]]]
+		return nextIsNum && isCNUnitRune(pinyinSyllableCN[tokens[idx+2]])
+	}
+	return false
+}
+
+// stripPinyinTones 去除声调符号和数字标调，转为小写
+func stripPinyinTones(s string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r >= '1' && r <= '5' {
+			builder.WriteRune(' ')
+			continue
+		}
+		found := false
+		for base, marks := range pinyinToneMarks {
+			for _, mark := range marks {
+				if r == mark {
+					builder.WriteRune(base)
+					found = true
+				}
+			}
+		}
+		if found {
+			continue
+		}
+		if unicode.IsSpace(r) || r == '-' || r == '\'' {
+			builder.WriteRune(' ')
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// pinyinSyllables 按长度降序排列的音节，用于切分连写的拼音
+var pinyinSyllables = func() []string {
+	syllables := make([]string, 0, len(pinyinSyllableCN))
+	for syllable := range pinyinSyllableCN {
+		syllables = append(syllables, syllable)
+	}
+	sort.Slice(syllables, func(i, j int) bool {
+		if len(syllables[i]) != len(syllables[j]) {
+			return len(syllables[i]) > len(syllables[j])
+		}
+		return syllables[i] < syllables[j]
+	})
+	return syllables
+}()
+
+// splitPinyinSyllables 将连写的拼音切分为音节（最长匹配，失败时回溯）
+func splitPinyinSyllables(s string) ([]string, bool) {
+	if s == "" {
+		return nil, true
+	}
+	for _, syllable := range pinyinSyllables {
+		if strings.HasPrefix(s, syllable) {
+			if rest, ok := splitPinyinSyllables(s[len(syllable):]); ok {
+				return append([]string{syllable}, rest...), true
+			}
+		}
+	}
+	return nil, false
+}
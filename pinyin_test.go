@@ -0,0 +1,107 @@
+package gocn2an
+
+import (
+	"testing"
+)
+
+func TestAn2pinyin(t *testing.T) {
+	testData := map[string]map[interface{}]string{
+		"tone": {
+			123:    "yì bǎi èr shí sān",
+			1:      "yī",
+			11:     "shí yī",
+			101:    "yì bǎi líng yī",
+			10000:  "yí wàn",
+			2000:   "liǎng qiān",
+			12000:  "yí wàn liǎng qiān",
+			120000: "shí èr wàn",
+			200:    "liǎng bǎi",
+			2200:   "liǎng qiān èr bǎi",
+			1.5:    "yī diǎn wǔ",
+			-2:     "fù èr",
+		},
+		"number": {
+			123: "yi4 bai3 er4 shi2 san1",
+			22:  "er4 shi2 er4",
+		},
+		"none": {
+			123: "yi bai er shi san",
+		},
+	}
+
+	a := NewAn2Cn()
+	for style, cases := range testData {
+		for input, expected := range cases {
+			result, err := a.An2pinyin(input, style)
+			if err != nil {
+				t.Errorf("An2pinyin(%v, %s) error: %v", input, style, err)
+				continue
+			}
+			if result != expected {
+				t.Errorf("An2pinyin(%v, %s) = %s, want %s", input, style, result, expected)
+			}
+		}
+	}
+}
+
+func TestCnToPinyin(t *testing.T) {
+	testData := map[string]string{
+		"一个":   "yí gè",
+		"一百":   "yì bǎi",
+		"一年":   "yì nián",
+		"一天":   "yì tiān",
+		"一亿":   "yí yì",
+		"两个":   "liǎng gè",
+		"一二三":  "yī èr sān",
+		"一点":   "yì diǎn",
+		"一点一":  "yī diǎn yī",
+		"壹佰":   "yì bǎi",
+		"九十九次": "jiǔ shí jiǔ cì",
+	}
+
+	for input, expected := range testData {
+		result, err := CnToPinyin(input, "tone")
+		if err != nil {
+			t.Errorf("CnToPinyin(%q) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("CnToPinyin(%q) = %s, want %s", input, result, expected)
+		}
+	}
+}
+
+func TestPinyin2an(t *testing.T) {
+	testData := map[string]float64{
+		"yi bai er shi san":      123,
+		"yibaiershisan":          123,
+		"yì bǎi èr shí sān":      123,
+		"yi4 bai3 er4 shi2 san1": 123,
+		"liang qian":             2000,
+		"shi yi":                 11,
+		"san yi wu qian wan":     350000000,
+		"san yi":                 31,
+		"shi yi wu qian wan":     1050000000,
+		"yi wan ling wu":         10005,
+		"fu san dian yi si":      -3.14,
+		"Yi Bai":                 100,
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.Pinyin2an(input, "normal")
+		if err != nil {
+			t.Errorf("Pinyin2an(%q, normal) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Pinyin2an(%q, normal) = %f, want %f", input, result, expected)
+		}
+	}
+
+	for _, input := range []string{"hello", "yi bai xx"} {
+		if _, err := c.Pinyin2an(input, "normal"); err == nil {
+			t.Errorf("Pinyin2an(%q, normal) should return error but got nil", input)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// relativeDayOffset 相对日期词与天数偏移
+var relativeDayOffset = map[string]int{
+	"大前天": -3,
+	"前天":  -2,
+	"昨天":  -1,
+	"昨日":  -1,
+	"今天":  0,
+	"今日":  0,
+	"明天":  1,
+	"明日":  1,
+	"后天":  2,
+	"大后天": 3,
+}
+
+// relativeYearOffset 相对年份词与年数偏移
+var relativeYearOffset = map[string]int{
+	"大前年": -3,
+	"前年":  -2,
+	"去年":  -1,
+	"今年":  0,
+	"明年":  1,
+	"后年":  2,
+	"大后年": 3,
+}
+
+// timePeriodStartHour 只有时段词时采用的时刻，如 明天下午 => 明天 14:00
+var timePeriodStartHour = map[string]int{
+	"凌晨": 0,
+	"半夜": 0,
+	"早上": 7,
+	"早晨": 7,
+	"清晨": 6,
+	"上午": 9,
+	"中午": 12,
+	"午后": 13,
+	"下午": 14,
+	"傍晚": 18,
+	"晚上": 20,
+	"夜里": 22,
+	"夜间": 22,
+}
+
+const (
+	relativeDayPattern    = `大前天|大后天|前天|昨天|昨日|今天|今日|明天|明日|后天`
+	relativeYearPattern   = `大前年|大后年|前年|去年|今年|明年|后年`
+	relativeWeekdayPrefix = `[上下这本]*个?(?:周|星期|礼拜)`
+	relativeMonthPrefix   = `[上下这本]+个?月`
+	relativeOffsetUnit    = `天|日|周|星期|礼拜|个月|年|小时|分钟`
+)
+
+var (
+	relativeDayRe     = regexp.MustCompile(fmt.Sprintf(`^(%s)$`, relativeDayPattern))
+	relativeYearRe    = regexp.MustCompile(fmt.Sprintf(`^(%[1]s)(?:(%[2]s|元|正|腊)月(?:(%[2]s)[日号])?)?$`, relativeYearPattern, dateNumPattern))
+	relativeWeekdayRe = regexp.MustCompile(`^([上下这本]*)个?(?:周|星期|礼拜)([日天一二三四五六1-7])$`)
+	relativeMonthRe   = regexp.MustCompile(fmt.Sprintf(`^([上下这本]+)个?月(?:(%s)[日号])?$`, dateNumPattern))
+	relativeOffsetRe  = regexp.MustCompile(fmt.Sprintf(`^(.+?(?:%s)半?)(以后|之后|后|以前|之前|前)$`, relativeOffsetUnit))
+
+	// 句子中的相对日期，后面可跟时段和时刻；今年、去年 等只在后跟月份时改写，避免 今年三十岁 => 202430岁
+	relativeTextRe = regexp.MustCompile(fmt.Sprintf(
+		`(?:(?:%[1]s)|(?:%[2]s)(?:(?:%[3]s)|元|正|腊)月(?:(?:%[3]s)[日号])?|(?:%[4]s)[日天一二三四五六]|(?:%[5]s)(?:(?:%[3]s)[日号])?|(?:%[6]s)[个個]?半?(?:%[7]s)半?(?:以后|之后|以前|之前|后|前))(?:%[8]s)?(?:(?:%[6]s)[点點时時](?:[钟鐘]|整|半|(?:%[6]s)刻|(?:%[6]s)分?)?)?`,
+		relativeDayPattern, relativeYearPattern, dateNumPattern, relativeWeekdayPrefix, relativeMonthPrefix,
+		durationNumPattern, relativeOffsetUnit, timePeriodPattern))
+)
+
+// ResolveRelativeDate 以 reference 为基准解析相对日期，如 明天下午、大后天、下周三、上个月五号、三天后、周日
+// 一周从周一开始；只有时段词时取该时段的典型时刻（如下午为 14:00）。
+// 结果的 Fields 标记实际给出的字段，带时段或时刻时包含 DateHasTime
+func (c *Cn2An) ResolveRelativeDate(inputs string, reference time.Time) (ParsedDate, error) {
+	data := strings.TrimSpace(normalizeText(inputs))
+	if data == "" {
+		return ParsedDate{}, errors.New("输入数据为空")
+	}
+
+	// 从长到短尝试拆分日期部分和时刻部分
+	runes := []rune(data)
+	for i := len(runes); i > 0; i-- {
+		result, err := c.resolveRelativeDay(string(runes[:i]), reference)
+		if err != nil {
+			continue
+		}
+		rest := string(runes[i:])
+		if rest == "" {
+			return result, nil
+		}
+		if hour, ok := timePeriodStartHour[rest]; ok {
+			result.Time = time.Date(result.Time.Year(), result.Time.Month(), result.Time.Day(), hour, 0, 0, 0, result.Time.Location())
+			result.Fields |= DateHasTime
+			return result, nil
+		}
+		if tod, err := c.ParseTimeOfDay(rest); err == nil {
+			result.Time = tod.On(result.Time)
+			result.Fields |= DateHasTime
+			return result, nil
+		}
+	}
+	return ParsedDate{}, fmt.Errorf("不符合格式的相对日期：%s", inputs)
+}
+
+// resolveRelativeDay 解析不含时刻的相对日期
+func (c *Cn2An) resolveRelativeDay(data string, reference time.Time) (ParsedDate, error) {
+	today := time.Date(reference.Year(), reference.Month(), reference.Day(), 0, 0, 0, 0, reference.Location())
+	fullDate := DateHasYear | DateHasMonth | DateHasDay
+
+	if subs := relativeDayRe.FindStringSubmatch(data); subs != nil {
+		return ParsedDate{Time: today.AddDate(0, 0, relativeDayOffset[subs[1]]), Fields: fullDate}, nil
+	}
+
+	if subs := relativeWeekdayRe.FindStringSubmatch(data); subs != nil {
+		weeks := strings.Count(subs[1], "下") - strings.Count(subs[1], "上")
+		weekday := weekdayCN[subs[2]]
+		// 周一为一周的第一天
+		monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		day := monday.AddDate(0, 0, 7*weeks+(int(weekday)+6)%7)
+		return ParsedDate{Time: day, Fields: fullDate | DateHasWeekday}, nil
+	}
+
+	if subs := relativeMonthRe.FindStringSubmatch(data); subs != nil {
+		months := strings.Count(subs[1], "下") - strings.Count(subs[1], "上")
+		first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location()).AddDate(0, months, 0)
+		if subs[2] == "" {
+			return ParsedDate{Time: first, Fields: DateHasYear | DateHasMonth}, nil
+		}
+		day, err := c.timeNumber(subs[2])
+		if err != nil {
+			return ParsedDate{}, err
+		}
+		result := first.AddDate(0, 0, day-1)
+		if day < 1 || result.Month() != first.Month() {
+			return ParsedDate{}, fmt.Errorf("日期超出范围：%s", data)
+		}
+		return ParsedDate{Time: result, Fields: fullDate}, nil
+	}
+
+	if subs := relativeYearRe.FindStringSubmatch(data); subs != nil {
+		year := today.Year() + relativeYearOffset[subs[1]]
+		rest := fmt.Sprintf("%d年", year)
+		if subs[2] != "" {
+			rest += subs[2] + "月"
+		}
+		if subs[3] != "" {
+			rest += subs[3] + "日"
+		}
+		return c.ParseDate(rest, WithDateReference(today))
+	}
+
+	if subs := relativeOffsetRe.FindStringSubmatch(data); subs != nil {
+		period, err := c.ParsePeriod(subs[1])
+		if err != nil {
+			return ParsedDate{}, err
+		}
+		if strings.HasSuffix(subs[2], "前") {
+			period = Period{Years: -period.Years, Months: -period.Months, Duration: -period.Duration}
+		}
+		if period.Duration%(24*time.Hour) != 0 {
+			// 三小时后 等精确到时刻
+			return ParsedDate{Time: period.AddTo(reference), Fields: fullDate | DateHasTime}, nil
+		}
+		return ParsedDate{Time: period.AddTo(today), Fields: fullDate}, nil
+	}
+
+	return ParsedDate{}, fmt.Errorf("不符合格式的相对日期：%s", data)
+}
+
+// FormatISODate 按字段输出 ISO 8601 日期：2006、2006-01、2006-01-02 或 2006-01-02 15:04
+func FormatISODate(d ParsedDate) string {
+	switch {
+	case d.Has(DateHasDay | DateHasTime):
+		if d.Time.Second() != 0 {
+			return d.Time.Format("2006-01-02 15:04:05")
+		}
+		return d.Time.Format("2006-01-02 15:04")
+	case d.Has(DateHasDay):
+		return d.Time.Format("2006-01-02")
+	case d.Has(DateHasMonth):
+		return d.Time.Format("2006-01")
+	default:
+		return d.Time.Format("2006")
+	}
+}
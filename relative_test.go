@@ -0,0 +1,54 @@
+package gocn2an
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveRelativeDate(t *testing.T) {
+	// 2024-06-05 为星期三
+	reference := time.Date(2024, 6, 5, 10, 30, 0, 0, time.UTC)
+	testData := map[string]string{
+		"今天":      "2024-06-05",
+		"明天下午":    "2024-06-06 14:00",
+		"明天下午三点":  "2024-06-06 15:00",
+		"后天晚上八点半": "2024-06-07 20:30",
+		"大后天":     "2024-06-08",
+		"大前天":     "2024-06-02",
+		"下周三":     "2024-06-12",
+		"下个星期三":   "2024-06-12",
+		"下下周一":    "2024-06-17",
+		"上周五":     "2024-05-31",
+		"这周一":     "2024-06-03",
+		"周日":      "2024-06-09",
+		"星期天上午十点": "2024-06-09 10:00",
+		"上个月五号":   "2024-05-05",
+		"下个月":     "2024-07",
+		"本月":      "2024-06",
+		"明年三月五日":  "2025-03-05",
+		"去年":      "2023",
+		"三天后":     "2024-06-08",
+		"两周前":     "2024-05-22",
+		"一个月后":    "2024-07-05",
+		"半年后":     "2024-12-05",
+		"三小时后":    "2024-06-05 13:30",
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.ResolveRelativeDate(input, reference)
+		if err != nil {
+			t.Errorf("ResolveRelativeDate(%q) error: %v", input, err)
+			continue
+		}
+		if got := FormatISODate(result); got != expected {
+			t.Errorf("ResolveRelativeDate(%q) = %s, want %s", input, got, expected)
+		}
+	}
+
+	for _, input := range []string{"", "三天", "上上个月三十一号", "明天下雨", "下周八"} {
+		if _, err := c.ResolveRelativeDate(input, reference); err == nil {
+			t.Errorf("ResolveRelativeDate(%q) should return error but got nil", input)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package gocn2an
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Token 分词结果，Start、End 为 Text 在原文中的字节偏移；
+// Tag 为可选的词性标记，m 开头（m、mq）表示数词或数量词，q 为量词，其余为非数词
+type Token struct {
+	Start int
+	End   int
+	Text  string
+	Tag   string
+}
+
+// Segmenter 分词器，Transform 只转换构成或位于数词、数量词开头的中文数字
+type Segmenter interface {
+	Segment(text string) []Token
+}
+
+// WithSegmenter 在 cn2an 句子转换中使用分词器判断数字边界：
+// 含中文数字但不是数词的词语（如 统一、一些、三星）保持原样
+func WithSegmenter(segmenter Segmenter) TransformOption {
+	return func(t *Transform) {
+		t.segmenter = segmenter
+	}
+}
+
+var (
+	// cnNumeralTokenRunes 可组成数词的中文字符
+	cnNumeralTokenRunes = func() string {
+		runes := "零〇一二三四五六七八九十百千万亿两幺壹贰叁肆伍陆柒捌玖拾佰仟廿卅卌皕点"
+		return runes + variantRunesOf(runes)
+	}()
+	// numeralTokenRunes 可组成数词的字符
+	numeralTokenRunes = cnNumeralTokenRunes + "0123456789."
+)
+
+// MaxMatchSegmenter 基于词典的正向最大匹配分词器，数字串及其后的量词合为一个数量词
+type MaxMatchSegmenter struct {
+	words  map[string]string
+	maxLen int
+}
+
+// NewMaxMatchSegmenter 创建最大匹配分词器，默认词典包含 ProtectedTermsCN、NumericTermsCN 和 SegmenterWordsCN，
+// words 追加或覆盖词条（词语 => 词性），可为 nil；完整的分词可接入外部分词器实现 Segmenter
+func NewMaxMatchSegmenter(words map[string]string) *MaxMatchSegmenter {
+	s := &MaxMatchSegmenter{words: map[string]string{}}
+	for _, word := range ProtectedTermsCN {
+		s.addWord(word, "l")
+	}
+	for _, word := range NumericTermsCN {
+		s.addWord(word, "mq")
+	}
+	for word, tag := range SegmenterWordsCN {
+		s.addWord(word, tag)
+	}
+	for word, tag := range words {
+		s.addWord(word, tag)
+	}
+	return s
+}
+
+// addWord 添加词条
+func (s *MaxMatchSegmenter) addWord(word, tag string) {
+	if word == "" {
+		return
+	}
+	s.words[word] = tag
+	if n := utf8.RuneCountInString(word); n > s.maxLen {
+		s.maxLen = n
+	}
+}
+
+// Segment 分词：词典词与数量词取较长者，等长时取词典词
+func (s *MaxMatchSegmenter) Segment(text string) []Token {
+	var tokens []Token
+	for pos := 0; pos < len(text); {
+		// 词典中最长的词
+		wordEnd, wordTag := 0, ""
+		end := pos
+		for n := 0; n < s.maxLen && end < len(text); n++ {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+			if tag, ok := s.words[text[pos:end]]; ok {
+				wordEnd, wordTag = end, tag
+			}
+		}
+
+		// 数字串，后接量词时合为数量词
+		numEnd, numTag := pos, "m"
+		for numEnd < len(text) {
+			r, size := utf8.DecodeRuneInString(text[numEnd:])
+			if !strings.ContainsRune(numeralTokenRunes, r) {
+				break
+			}
+			numEnd += size
+		}
+		if numEnd > pos {
+			if loc := measureWordPrefixRe.FindStringIndex(text[numEnd:]); loc != nil {
+				numEnd += loc[1]
+				numTag = "mq"
+			}
+		}
+
+		switch {
+		case wordEnd > 0 && wordEnd >= numEnd:
+			tokens = append(tokens, Token{Start: pos, End: wordEnd, Text: text[pos:wordEnd], Tag: wordTag})
+			pos = wordEnd
+		case numEnd > pos:
+			tokens = append(tokens, Token{Start: pos, End: numEnd, Text: text[pos:numEnd], Tag: numTag})
+			pos = numEnd
+		default:
+			_, size := utf8.DecodeRuneInString(text[pos:])
+			tokens = append(tokens, Token{Start: pos, End: pos + size, Text: text[pos : pos+size]})
+			pos += size
+		}
+	}
+	return tokens
+}
+
+// isNumeralToken 判断分词结果是否为数词或数量词；没有词性时按 数字串 + 可选量词 判断
+func isNumeralToken(token Token) bool {
+	if token.Tag != "" {
+		return strings.HasPrefix(token.Tag, "m") || token.Tag == "q"
+	}
+	rest := strings.TrimLeft(token.Text, numeralTokenRunes)
+	if rest == token.Text {
+		return false
+	}
+	loc := measureWordPrefixRe.FindStringIndex(rest)
+	return rest == "" || loc != nil && loc[1] == len(rest)
+}
+
+// segmenterSpans 返回含中文数字但不是数词的词语位置，这些词语保持原样
+func (t *Transform) segmenterSpans(s string) []protectedSpan {
+	var spans []protectedSpan
+	for _, token := range t.segmenter.Segment(s) {
+		if token.Start < 0 || token.End > len(s) || token.Start >= token.End {
+			continue
+		}
+		text := s[token.Start:token.End]
+		if !strings.ContainsAny(text, cnNumeralTokenRunes) || isNumeralToken(token) {
+			continue
+		}
+		spans = append(spans, protectedSpan{start: token.Start, end: token.End, output: text})
+	}
+	return spans
+}
@@ -0,0 +1,51 @@
+package gocn2an
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaxMatchSegmenter(t *testing.T) {
+	testData := map[string]string{
+		"三星期后买了两个": "三星期/mq 后 买 了 两个/mq",
+		"一流的三星手机":  "一流/a 的 三星/nz 手 机",
+		"十一假期八千万":  "十一假期/l 八千万/m",
+		"5w粉丝":     "5/m w 粉 丝",
+	}
+
+	// 默认词典之上覆盖 三星 的词性
+	segmenter := NewMaxMatchSegmenter(map[string]string{"三星": "nz"})
+	for input, expected := range testData {
+		var parts []string
+		for _, token := range segmenter.Segment(input) {
+			if input[token.Start:token.End] != token.Text {
+				t.Errorf("Segment(%q) token %q has offsets [%d, %d)", input, token.Text, token.Start, token.End)
+			}
+			if token.Tag != "" {
+				parts = append(parts, token.Text+"/"+token.Tag)
+			} else {
+				parts = append(parts, token.Text)
+			}
+		}
+		if got := strings.Join(parts, " "); got != expected {
+			t.Errorf("Segment(%q) = %s, want %s", input, got, expected)
+		}
+	}
+}
+
+func TestIsNumeralToken(t *testing.T) {
+	testData := map[Token]bool{
+		{Text: "三个"}:           true,
+		{Text: "二十"}:           true,
+		{Text: "一流"}:           false,
+		{Text: "三月", Tag: "t"}: false,
+		{Text: "三月", Tag: "m"}: true,
+		{Text: "个", Tag: "q"}:  true,
+	}
+
+	for token, expected := range testData {
+		if got := isNumeralToken(token); got != expected {
+			t.Errorf("isNumeralToken(%+v) = %v, want %v", token, got, expected)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package gocn2an
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithSlangUnit 设置 smart 模式下数量级后缀的数值（不区分大小写），如 m 表示百万；
+// value 为 0 时移除该后缀
+func WithSlangUnit(suffix string, value float64) Cn2AnOption {
+	return func(c *Cn2An) {
+		suffix = strings.ToLower(suffix)
+		if value == 0 {
+			delete(c.slangUnits, suffix)
+			return
+		}
+		c.slangUnits[suffix] = value
+	}
+}
+
+// slangSuffixPattern 返回匹配「数字 + 数量级后缀」的正则（不含锚点）
+// 分组 1 为数字部分，分组 2 为后缀
+func (c *Cn2An) slangSuffixPattern() string {
+	suffixes := make([]string, 0, len(c.slangUnits))
+	for suffix := range c.slangUnits {
+		suffixes = append(suffixes, regexp.QuoteMeta(suffix))
+	}
+	// 长后缀优先：kw 先于 k
+	sort.Slice(suffixes, func(i, j int) bool {
+		if len(suffixes[i]) != len(suffixes[j]) {
+			return len(suffixes[i]) > len(suffixes[j])
+		}
+		return suffixes[i] < suffixes[j]
+	})
+	if len(suffixes) == 0 {
+		return `(-?\d+(?:\.\d+)?)\s*个?([^\s\S])`
+	}
+	return `(-?\d+(?:\.\d+)?)\s*个?(?i:(` + strings.Join(suffixes, "|") + `))`
+}
+
+// slangConvert 转换 5w、3k、1.2kw、8W+、20个w、5 million 等写法
+func (c *Cn2An) slangConvert(inputs string) (float64, bool) {
+	subs := c.slangPattern.FindStringSubmatch(strings.TrimSpace(inputs))
+	if subs == nil {
+		return 0, false
+	}
+	numVal, err := strconv.ParseFloat(subs[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	multiplier, ok := c.slangUnits[strings.ToLower(subs[2])]
+	if !ok {
+		return 0, false
+	}
+	precision := 0
+	if idx := strings.Index(subs[1], "."); idx != -1 {
+		precision = len(subs[1]) - idx - 1
+	}
+	return roundToDecimal(numVal*multiplier, precision), true
+}
@@ -0,0 +1,116 @@
+package gocn2an
+
+import (
+	"testing"
+)
+
+func TestCn2anSlang(t *testing.T) {
+	testData := map[string]float64{
+		"5w":        50000,
+		"5W":        50000,
+		"3k":        3000,
+		"1.2kw":     12000000,
+		"8W+":       80000,
+		"20个w":      200000,
+		"2m":        2000000,
+		"1.5b":      1500000000,
+		"5 million": 5000000,
+		"-3.5k":     -3500,
+		"1.2万":      12000,
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.Cn2an(input, "smart")
+		if err != nil {
+			t.Errorf("Cn2an(%q, smart) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Cn2an(%q, smart) = %f, want %f", input, result, expected)
+		}
+	}
+
+	if _, err := c.Cn2an("5w", "normal"); err == nil {
+		t.Errorf("Cn2an(%q, normal) should return error but got nil", "5w")
+	}
+}
+
+func TestCn2anSlangOption(t *testing.T) {
+	c := NewCn2An(WithSlangUnit("m", 0), WithSlangUnit("lakh", 100000))
+	if _, err := c.Cn2an("2m", "smart"); err == nil {
+		t.Errorf("Cn2an(%q, smart) should return error but got nil", "2m")
+	}
+	result, err := c.Cn2an("3 Lakh", "smart")
+	if err != nil {
+		t.Fatalf("Cn2an(%q, smart) error: %v", "3 Lakh", err)
+	}
+	if result != 300000 {
+		t.Errorf("Cn2an(%q, smart) = %f, want %f", "3 Lakh", result, 300000.0)
+	}
+}
+
+func TestTransformSlangCn2an(t *testing.T) {
+	testData := map[string]string{
+		"月薪5w起":                "月薪50000起",
+		"粉丝8W+":                "粉丝80000+",
+		"融资1.2kw":              "融资12000000",
+		"卖了20个w":               "卖了200000",
+		"revenue of 5 million": "revenue of 5000000",
+		"网页web":                "网页web",
+		"mp3k歌":                "mp3k歌",
+	}
+
+	tr := NewTransform(WithSlangMagnitudes())
+	for input, expected := range testData {
+		result, err := tr.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %s, want %s", input, result, expected)
+		}
+	}
+}
+
+func TestTransformSlangUnitOption(t *testing.T) {
+	tr := NewTransform(WithSlangMagnitudes(), WithCn2AnOptions(WithSlangUnit("m", 0), WithSlangUnit("lakh", 100000)))
+	testData := map[string]string{
+		"身高1.8m":   "身高1.8m",
+		"融资3 lakh": "融资300000",
+		"月薪5w起":    "月薪50000起",
+		"一百二十三个人":  "123个人",
+	}
+	for input, expected := range testData {
+		result, err := tr.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %s, want %s", input, result, expected)
+		}
+	}
+}
+
+func TestTransformSlangDisabledByDefault(t *testing.T) {
+	testData := []string{
+		"身高1.8m",
+		"100m短跑",
+		"4K电视",
+		"月薪5w起",
+	}
+
+	tr := NewTransform()
+	for _, input := range testData {
+		result, err := tr.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != input {
+			t.Errorf("Transform(%q, cn2an) = %s, want %s", input, result, input)
+		}
+	}
+}
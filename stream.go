@@ -0,0 +1,273 @@
+package gocn2an
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// streamChunkSize 流式转换每次至少处理的字节数
+	streamChunkSize = 64 * 1024
+	// streamContextSize 流式转换在切分点前后保留的上下文字节数，需大于单个数字、日期或算式的长度
+	streamContextSize = 1024
+)
+
+// streamTransformer 流式转换的缓冲区：buf[:emitStart] 为已输出部分的上文，其后为待输出部分；
+// 每次转换整个缓冲区，只输出切分点之前的结果，切分点之后至少保留 context 字节作为下文
+type streamTransformer struct {
+	t         *Transform
+	method    string
+	chunk     int
+	context   int
+	buf       []byte
+	emitStart int
+	// offset buf[0] 在整个输入中的位置
+	offset int
+	// next 上次没有合适的切分点时，下次转换前缓冲区至少达到的长度
+	next int
+}
+
+// newStreamTransformer 创建流式转换缓冲区
+func newStreamTransformer(t *Transform, method string, chunk, context int) (*streamTransformer, error) {
+	if method != "cn2an" && method != "an2cn" {
+		return nil, fmt.Errorf("error method: %s, only support 'cn2an' and 'an2cn'", method)
+	}
+	return &streamTransformer{t: t, method: method, chunk: chunk, context: context}, nil
+}
+
+// ready 缓冲区是否足够输出一段
+func (s *streamTransformer) ready() bool {
+	return len(s.buf) >= max(s.next, s.emitStart+s.chunk+s.context)
+}
+
+// flush 转换缓冲区并返回可以输出的部分；final 为 true 时输出全部
+func (s *streamTransformer) flush(final bool) (string, error) {
+	end := len(s.buf)
+	if !final {
+		// 末尾不完整的 UTF-8 字符留到下次
+		for i := end - 1; i >= 0 && i >= end-utf8.UTFMax; i-- {
+			if utf8.RuneStart(s.buf[i]) {
+				if !utf8.FullRune(s.buf[i:end]) {
+					end = i
+				}
+				break
+			}
+		}
+	}
+
+	window := string(s.buf[:end])
+	output, alignment, failures, err := s.t.transformWithFailures(window, s.method)
+	if err != nil {
+		return "", err
+	}
+	from := outputBoundary(alignment, s.emitStart)
+
+	if final {
+		err := s.reportFailures(failures, len(window))
+		s.offset += len(s.buf)
+		s.buf, s.emitStart, s.next = s.buf[:0], 0, 0
+		return output[from:], err
+	}
+
+	cut := s.cutPoint(window, alignment, failures)
+	if cut <= s.emitStart {
+		s.next = len(s.buf) + s.chunk
+		return "", nil
+	}
+	s.next = 0
+	to := outputBoundary(alignment, cut)
+	// 严格模式下的转换失败与本段输出一起返回
+	err = s.reportFailures(failures, cut)
+
+	// 保留切分点之前 context 字节作为下一次的上文
+	keep := cut - s.context
+	if keep < 0 {
+		keep = 0
+	}
+	for keep > 0 && !utf8.RuneStart(s.buf[keep]) {
+		keep--
+	}
+	// 上文不从改写片段中间开始，如 |y| 的两条竖线
+	for _, span := range alignment {
+		if span.Changed && span.SrcStart < keep && keep < span.SrcEnd {
+			keep = span.SrcStart
+			break
+		}
+	}
+	s.buf = s.buf[:copy(s.buf, s.buf[keep:])]
+	s.emitStart = cut - keep
+	s.offset += keep
+	return output[from:to], err
+}
+
+// reportFailures 报告起点位于本次输出范围 [emitStart, cut) 内的转换失败，位置换为整个输入中的位置
+func (s *streamTransformer) reportFailures(failures []ConversionError, cut int) error {
+	var reported []ConversionError
+	for _, failure := range failures {
+		if failure.Start >= s.emitStart && failure.Start < cut {
+			failure.Start, failure.End = failure.Start+s.offset, failure.End+s.offset
+			reported = append(reported, failure)
+		}
+	}
+	return s.t.reportFailures(reported)
+}
+
+// cutPoint 选择切分点：优先在 context 字节下文之前的最后一个换行，其次空白，且不落在改写片段或未能转换的片段内部
+func (s *streamTransformer) cutPoint(window string, alignment Alignment, failures []ConversionError) int {
+	limit := len(window) - s.context
+	if limit <= s.emitStart {
+		return 0
+	}
+
+	cut := 0
+	if i := bytes.LastIndexByte([]byte(window[s.emitStart:limit]), '\n'); i != -1 {
+		cut = s.emitStart + i + 1
+	} else if i := bytes.LastIndexFunc([]byte(window[s.emitStart:limit]), unicode.IsSpace); i != -1 {
+		_, size := utf8.DecodeRuneInString(window[s.emitStart+i:])
+		cut = s.emitStart + i + size
+	} else {
+		cut = limit
+		for cut > 0 && !utf8.RuneStart(window[cut]) {
+			cut--
+		}
+	}
+
+	for _, span := range alignment {
+		if span.Changed && span.SrcStart < cut && cut < span.SrcEnd {
+			cut = span.SrcStart
+			break
+		}
+	}
+	for _, failure := range failures {
+		if failure.Start < cut && cut < failure.End {
+			cut = failure.Start
+		}
+	}
+	return cut
+}
+
+// outputBoundary 输入中的切分点 pos 在输出中的位置；紧贴切分点的插入片段归入切分点之后，
+// 同一位置在前一次转换中作为结束、在后一次转换中作为起点时，插入的文本只输出一次
+func outputBoundary(alignment Alignment, pos int) int {
+	for _, span := range alignment {
+		if span.SrcStart >= pos {
+			return span.DstStart
+		}
+		if pos < span.SrcEnd {
+			if span.Changed {
+				return span.DstStart
+			}
+			return span.DstStart + pos - span.SrcStart
+		}
+	}
+	if len(alignment) == 0 {
+		return 0
+	}
+	return alignment[len(alignment)-1].DstEnd
+}
+
+// transformReader 流式转换的 io.Reader
+type transformReader struct {
+	r      io.Reader
+	stream *streamTransformer
+	out    []byte
+	err    error
+}
+
+// NewReader 返回读取 r 并转换其内容的 io.Reader，method 为 cn2an 或 an2cn；
+// 只缓冲切分点前后必要的上下文，输出与对全文调用 Transform 相同；
+// 严格模式下读出失败片段所在的一段后返回 ConversionErrors
+func (t *Transform) NewReader(r io.Reader, method string) io.Reader {
+	stream, err := newStreamTransformer(t, method, streamChunkSize, streamContextSize)
+	return &transformReader{r: r, stream: stream, err: err}
+}
+
+func (tr *transformReader) Read(p []byte) (int, error) {
+	for len(tr.out) == 0 && tr.err == nil {
+		tr.fill()
+	}
+	if len(tr.out) > 0 {
+		n := copy(p, tr.out)
+		tr.out = tr.out[n:]
+		return n, nil
+	}
+	return 0, tr.err
+}
+
+// fill 读取数据直到可以输出一段或读到结尾
+func (tr *transformReader) fill() {
+	s := tr.stream
+	chunk := make([]byte, s.chunk)
+	for !s.ready() {
+		n, err := tr.r.Read(chunk)
+		s.buf = append(s.buf, chunk[:n]...)
+		if err == io.EOF {
+			output, err := s.flush(true)
+			tr.out = append(tr.out, output...)
+			tr.err = err
+			if err == nil {
+				tr.err = io.EOF
+			}
+			return
+		}
+		if err != nil {
+			tr.err = err
+			return
+		}
+	}
+	output, err := s.flush(false)
+	tr.out = append(tr.out, output...)
+	tr.err = err
+}
+
+// transformWriter 流式转换的 io.WriteCloser
+type transformWriter struct {
+	w      io.Writer
+	stream *streamTransformer
+	err    error
+}
+
+// NewWriter 返回将写入内容转换后写到 w 的 io.WriteCloser，method 为 cn2an 或 an2cn；
+// 需调用 Close 输出缓冲区中剩余的内容，Close 不会关闭 w；严格模式下写出失败片段所在的一段后返回 ConversionErrors
+func (t *Transform) NewWriter(w io.Writer, method string) io.WriteCloser {
+	stream, err := newStreamTransformer(t, method, streamChunkSize, streamContextSize)
+	return &transformWriter{w: w, stream: stream, err: err}
+}
+
+func (tw *transformWriter) Write(p []byte) (int, error) {
+	if tw.err != nil {
+		return 0, tw.err
+	}
+	s := tw.stream
+	s.buf = append(s.buf, p...)
+	for s.ready() {
+		output, err := s.flush(false)
+		if _, writeErr := io.WriteString(tw.w, output); err == nil {
+			err = writeErr
+		}
+		if err != nil {
+			tw.err = err
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close 转换并输出缓冲区中剩余的内容
+func (tw *transformWriter) Close() error {
+	if tw.err != nil {
+		return tw.err
+	}
+	output, err := tw.stream.flush(true)
+	if _, writeErr := io.WriteString(tw.w, output); err == nil {
+		err = writeErr
+	}
+	tw.err = err
+	if err == nil {
+		tw.err = fmt.Errorf("transform writer closed")
+	}
+	return err
+}
@@ -0,0 +1,158 @@
+package gocn2an
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// streamFragments 随机拼接的片段：数字、日期、算式、保护词语及分隔符
+var streamFragments = map[string][]string{
+	"cn2an": {
+		"二零二四年三月五日", "下午三点半", "一心一意", "两千五百年", "百分之五十", "三分之一",
+		"零下十摄氏度", "两个苹果", "一半", "第二季度", "一百二十三亿元", "负十", "负半", "十",
+		"一千二百三十四万五千六百七十八点九", "五月廿三日", "等了", "的人", "三点", "两",
+	},
+	"an2cn": {
+		"2024年3月5日", "14:30", "3-2=1", "|y|≥5%", "-10℃", "1/3", "123456789.12345元",
+		"Q2", "50%", "80年代", "3.5倍", "2%", "2-1%", "-", "开会", "的人", "1", "12345678901234",
+	},
+}
+
+// streamSeparators 片段之间的分隔，空串使片段直接相连
+var streamSeparators = []string{"", " ", "\n", "，", "。", "的"}
+
+// randomStreamInput 随机生成测试文本；至多两个片段直接相连，使数字、算式不超过上下文长度
+func randomStreamInput(r *rand.Rand, method string) string {
+	fragments := streamFragments[method]
+	var builder strings.Builder
+	joined := false
+	for i := r.Intn(60); i >= 0; i-- {
+		builder.WriteString(fragments[r.Intn(len(fragments))])
+		separator := streamSeparators[r.Intn(len(streamSeparators))]
+		if separator == "" && joined {
+			separator = " "
+		}
+		joined = separator == ""
+		builder.WriteString(separator)
+	}
+	return builder.String()
+}
+
+// readStream 以 Reader 转换 input，每次只读一个字节
+func readStream(transform *Transform, method, input string, chunk, context int) (string, error) {
+	stream, _ := newStreamTransformer(transform, method, chunk, context)
+	reader := &transformReader{r: iotest.OneByteReader(strings.NewReader(input)), stream: stream}
+	got, err := io.ReadAll(reader)
+	return string(got), err
+}
+
+// writeStream 以 Writer 转换 input，每次写入 step 字节，会截断 UTF-8 字符
+func writeStream(transform *Transform, method, input string, chunk, context, step int) (string, error) {
+	var buf bytes.Buffer
+	stream, _ := newStreamTransformer(transform, method, chunk, context)
+	writer := &transformWriter{w: &buf, stream: stream}
+	for i := 0; i < len(input); i += step {
+		if _, err := writer.Write([]byte(input[i:min(i+step, len(input))])); err != nil {
+			return buf.String(), err
+		}
+	}
+	err := writer.Close()
+	return buf.String(), err
+}
+
+func TestStreamTransform(t *testing.T) {
+	transform := NewTransform()
+	r := rand.New(rand.NewSource(1))
+	for _, method := range []string{"cn2an", "an2cn"} {
+		for i := 0; i < 40; i++ {
+			input := randomStreamInput(r, method)
+			expected, err := transform.Transform(input, method)
+			if err != nil {
+				t.Fatalf("Transform(%q, %s) error: %v", input, method, err)
+			}
+
+			chunk, context := 8+r.Intn(24), 112+r.Intn(48)
+			if got, err := readStream(transform, method, input, chunk, context); err != nil || got != expected {
+				t.Errorf("NewReader(%q, %s, %d, %d) = %q, %v, want %q", input, method, chunk, context, got, err, expected)
+			}
+			step := 1 + r.Intn(16)
+			if got, err := writeStream(transform, method, input, chunk, context, step); err != nil || got != expected {
+				t.Errorf("NewWriter(%q, %s, %d, %d, %d) = %q, %v, want %q", input, method, chunk, context, step, got, err, expected)
+			}
+		}
+
+		// 切分点两侧有插入或删除的片段
+		for _, input := range []string{strings.Repeat("负十负半十 ", 30), strings.Repeat("2%2-1% ", 30)} {
+			expected, _ := transform.Transform(input, method)
+			for chunk := 1; chunk <= 16; chunk++ {
+				if got, err := readStream(transform, method, input, chunk, 48); err != nil || got != expected {
+					t.Errorf("NewReader(%q, %s, %d, 48) = %q, %v, want %q", input, method, chunk, got, err, expected)
+				}
+			}
+		}
+
+		// 默认大小
+		input := strings.Repeat(randomStreamInput(r, method)+"\n", 200)
+		expected, _ := transform.Transform(input, method)
+		got, err := io.ReadAll(transform.NewReader(strings.NewReader(input), method))
+		if err != nil || string(got) != expected {
+			t.Errorf("NewReader(%s) = %q, %v, want %q", method, got, err, expected)
+		}
+	}
+}
+
+func TestStreamTransformMath(t *testing.T) {
+	// 算式密集的输入超过一个块，对齐须保持线性
+	transform := NewTransform()
+	line := "计算1+1=2，3×4=12，x≥5，2^3 - |y|。\n"
+	input := strings.Repeat(line, streamChunkSize*5/4/len(line))
+	expected, _ := transform.Transform(input, "an2cn")
+	got, err := io.ReadAll(transform.NewReader(strings.NewReader(input), "an2cn"))
+	if err != nil || string(got) != expected {
+		t.Errorf("NewReader(math) = %v, output matches Transform: %v", err, string(got) == expected)
+	}
+
+	var buf bytes.Buffer
+	writer := transform.NewWriter(&buf, "an2cn")
+	io.Copy(writer, strings.NewReader(input))
+	if err := writer.Close(); err != nil || buf.String() != expected {
+		t.Errorf("NewWriter(math) = %v, output matches Transform: %v", err, buf.String() == expected)
+	}
+}
+
+func TestStreamTransformInsertion(t *testing.T) {
+	// 干支纪年标注只在原文之后插入文本，插入片段紧贴切分点
+	transform := NewTransform(WithGanzhiAnnotation(2024))
+	input := strings.Repeat("甲辰年", 40)
+	expected, _ := transform.Transform(input, "cn2an")
+	for chunk := 1; chunk <= 16; chunk++ {
+		if got, err := readStream(transform, "cn2an", input, chunk, 48); err != nil || got != expected {
+			t.Errorf("NewReader(%q, %d, 48) = %q, %v, want %q", input, chunk, got, err, expected)
+		}
+	}
+}
+
+func TestStreamTransformError(t *testing.T) {
+	transform := NewTransform()
+	if _, err := io.ReadAll(transform.NewReader(strings.NewReader("一"), "abc")); err == nil {
+		t.Error("NewReader with invalid method should return error")
+	}
+	writer := transform.NewWriter(io.Discard, "abc")
+	if _, err := writer.Write([]byte("一")); err == nil {
+		t.Error("NewWriter with invalid method should return error")
+	}
+
+	var buf bytes.Buffer
+	writer = transform.NewWriter(&buf, "cn2an")
+	writer.Write([]byte("一百"))
+	if err := writer.Close(); err != nil || buf.String() != "100" {
+		t.Errorf("NewWriter = %q, %v, want %q", buf.String(), err, "100")
+	}
+	if _, err := writer.Write([]byte("一")); err == nil {
+		t.Error("Write after Close should return error")
+	}
+}
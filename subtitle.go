@@ -0,0 +1,297 @@
+package gocn2an
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Subtitle SRT 或 WebVTT 字幕
+type Subtitle struct {
+	// Format 格式：srt 或 vtt
+	Format string
+	// Header WebVTT 的文件头块（WEBVTT 行及其后的元数据），SRT 为空
+	Header string
+	Cues   []SubtitleCue
+	// Notes WebVTT 中位于最后一条字幕之后的 NOTE 等块，原样保留
+	Notes []string
+	// LineEnding 换行符，读取时按原文件设置为 \n 或 \r\n
+	LineEnding string
+}
+
+// SubtitleCue 一条字幕
+type SubtitleCue struct {
+	// ID SRT 的序号或 WebVTT 的标识，可为空
+	ID string
+	// Timing 时间行原文（含 WebVTT 的显示设置），写出时原样保留；为空时按 Start、End 生成
+	Timing string
+	Start  time.Duration
+	End    time.Duration
+	// Text 字幕文本，多行以 \n 连接
+	Text string
+	// Notes WebVTT 中位于该条字幕之前的 NOTE、STYLE、REGION 块，原样保留
+	Notes []string
+	// Alignment 转换后的 Text 与原 Text 的对齐，只由 TransformSubtitleWithAlignment 设置
+	Alignment Alignment
+}
+
+var (
+	// subtitleTimingRe 时间行：00:01:02,500 --> 00:01:04,000、01:02.500 --> 01:04.000 align:start
+	subtitleTimingRe = regexp.MustCompile(`^\s*((?:\d+:)?\d{1,2}:\d{1,2}[,.]\d{1,3})\s*-->\s*((?:\d+:)?\d{1,2}:\d{1,2}[,.]\d{1,3})(?:\s+.*)?$`)
+	// subtitleTagRe 字幕文本中的样式标签：<i>、<c.yellow>、<v Roger>、<00:00:01.000>、{\an8}
+	subtitleTagRe = regexp.MustCompile(`<[^<>\n]*>|\{\\[^{}\n]*\}`)
+)
+
+// ReadSRT 读取 SRT 字幕
+func ReadSRT(r io.Reader) (*Subtitle, error) {
+	return readSubtitle(r, "srt")
+}
+
+// ReadWebVTT 读取 WebVTT 字幕
+func ReadWebVTT(r io.Reader) (*Subtitle, error) {
+	return readSubtitle(r, "vtt")
+}
+
+// readSubtitle 按空行分块读取字幕
+func readSubtitle(r io.Reader, format string) (*Subtitle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimPrefix(string(data), "\ufeff")
+	sub := &Subtitle{Format: format, LineEnding: "\n"}
+	if strings.Contains(content, "\r\n") {
+		sub.LineEnding = "\r\n"
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+
+	blocks := splitSubtitleBlocks(content)
+	if format == "vtt" {
+		if len(blocks) == 0 || !isWebVTTKeyword(blocks[0], "WEBVTT") {
+			return nil, fmt.Errorf("不符合格式的 WebVTT 字幕：缺少 WEBVTT 文件头")
+		}
+		sub.Header = blocks[0]
+		blocks = blocks[1:]
+	}
+
+	var notes []string
+	for _, block := range blocks {
+		if format == "vtt" && (isWebVTTKeyword(block, "NOTE") || isWebVTTKeyword(block, "STYLE") || isWebVTTKeyword(block, "REGION")) {
+			notes = append(notes, block)
+			continue
+		}
+		cue, err := parseSubtitleCue(block)
+		if err != nil {
+			return nil, err
+		}
+		cue.Notes, notes = notes, nil
+		sub.Cues = append(sub.Cues, cue)
+	}
+	sub.Notes = notes
+	return sub, nil
+}
+
+// splitSubtitleBlocks 按空行切分
+func splitSubtitleBlocks(content string) []string {
+	var blocks []string
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if lines != nil {
+				blocks = append(blocks, strings.Join(lines, "\n"))
+				lines = nil
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if lines != nil {
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return blocks
+}
+
+// isWebVTTKeyword 块是否以关键字开头，关键字后为空白或结尾
+func isWebVTTKeyword(block, keyword string) bool {
+	rest, ok := strings.CutPrefix(block, keyword)
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n')
+}
+
+// parseSubtitleCue 解析一条字幕：可选的序号或标识、时间行、文本
+func parseSubtitleCue(block string) (SubtitleCue, error) {
+	lines := strings.Split(block, "\n")
+	var cue SubtitleCue
+	if !strings.Contains(lines[0], "-->") {
+		cue.ID = lines[0]
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return cue, fmt.Errorf("不符合格式的字幕：%s", block)
+	}
+	m := subtitleTimingRe.FindStringSubmatch(lines[0])
+	if m == nil {
+		return cue, fmt.Errorf("不符合格式的字幕时间：%s", lines[0])
+	}
+	cue.Timing = lines[0]
+	cue.Start, _ = parseSubtitleTime(m[1])
+	cue.End, _ = parseSubtitleTime(m[2])
+	cue.Text = strings.Join(lines[1:], "\n")
+	return cue, nil
+}
+
+// parseSubtitleTime 解析 [时:]分:秒,毫秒 或 [时:]分:秒.毫秒
+func parseSubtitleTime(s string) (time.Duration, error) {
+	sep := strings.LastIndexAny(s, ",.")
+	if sep == -1 {
+		return 0, fmt.Errorf("不符合格式的字幕时间：%s", s)
+	}
+	millis, err := strconv.Atoi((s[sep+1:] + "00")[:3])
+	if err != nil {
+		return 0, fmt.Errorf("不符合格式的字幕时间：%s", s)
+	}
+	var d time.Duration
+	for _, part := range strings.Split(s[:sep], ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("不符合格式的字幕时间：%s", s)
+		}
+		d = d*60 + time.Duration(n)*time.Second
+	}
+	return d + time.Duration(millis)*time.Millisecond, nil
+}
+
+// formatSubtitleTime 输出时间，sep 为毫秒前的分隔符
+func formatSubtitleTime(d time.Duration, sep string) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// WriteTo 按 Format 写出字幕
+func (s *Subtitle) WriteTo(w io.Writer) (int64, error) {
+	var blocks []string
+	if s.Format == "vtt" {
+		header := s.Header
+		if header == "" {
+			header = "WEBVTT"
+		}
+		blocks = append(blocks, header)
+	}
+	for i, cue := range s.Cues {
+		blocks = append(blocks, cue.Notes...)
+
+		var lines []string
+		if cue.ID != "" {
+			lines = append(lines, cue.ID)
+		} else if s.Format != "vtt" {
+			lines = append(lines, strconv.Itoa(i+1))
+		}
+		timing := cue.Timing
+		if timing == "" {
+			sep := ","
+			if s.Format == "vtt" {
+				sep = "."
+			}
+			timing = formatSubtitleTime(cue.Start, sep) + " --> " + formatSubtitleTime(cue.End, sep)
+		}
+		lines = append(lines, timing)
+		if cue.Text != "" {
+			lines = append(lines, cue.Text)
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	blocks = append(blocks, s.Notes...)
+
+	content := strings.Join(blocks, "\n\n") + "\n"
+	if s.LineEnding != "" && s.LineEnding != "\n" {
+		content = strings.ReplaceAll(content, "\n", s.LineEnding)
+	}
+	n, err := io.WriteString(w, content)
+	return int64(n), err
+}
+
+// SubtitleError 一条字幕中未能转换的数字，Err 中的位置相对于该字幕的 Text
+type SubtitleError struct {
+	// Cue 字幕在 Cues 中的下标，从 0 开始
+	Cue int
+	Err ConversionErrors
+}
+
+func (e SubtitleError) Error() string {
+	return fmt.Sprintf("第 %d 条字幕：%v", e.Cue+1, e.Err)
+}
+
+func (e SubtitleError) Unwrap() error {
+	return e.Err
+}
+
+// SubtitleErrors 严格模式下按字幕分组的全部转换失败
+type SubtitleErrors []SubtitleError
+
+func (e SubtitleErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "；")
+}
+
+func (e SubtitleErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// TransformSubtitle 转换每条字幕的文本，method 为 cn2an 或 an2cn；
+// 序号、时间行、NOTE 等块以及文本中的样式标签保持原样。
+// 严格模式下全部字幕转换完成后返回 SubtitleErrors，每条字幕的失败位置相对于其 Text
+func (t *Transform) TransformSubtitle(s *Subtitle, method string) error {
+	return t.transformSubtitle(s, method, false)
+}
+
+// TransformSubtitleWithAlignment 同 TransformSubtitle，并在每条字幕的 Alignment 中记录转换后文本与原文本的对齐
+func (t *Transform) TransformSubtitleWithAlignment(s *Subtitle, method string) error {
+	return t.transformSubtitle(s, method, true)
+}
+
+// transformSubtitle 逐条转换字幕文本
+func (t *Transform) transformSubtitle(s *Subtitle, method string, align bool) error {
+	if method != "cn2an" && method != "an2cn" {
+		return fmt.Errorf("error method: %s, only support 'cn2an' and 'an2cn'", method)
+	}
+	var failures SubtitleErrors
+	for i := range s.Cues {
+		cue := &s.Cues[i]
+		output, alignment, err := t.transformMarkup(cue.Text, method, subtitleTextSpans(cue.Text), align)
+		if cueFailures, ok := err.(ConversionErrors); ok {
+			failures = append(failures, SubtitleError{Cue: i, Err: cueFailures})
+		} else if err != nil {
+			return err
+		}
+		cue.Text = output
+		if align {
+			cue.Alignment = alignment
+		}
+	}
+	if failures != nil {
+		return failures
+	}
+	return nil
+}
+
+// subtitleTextSpans 将字幕文本切分为样式标签和文本
+func subtitleTextSpans(s string) []markupSpan {
+	var b markupSpans
+	last := 0
+	for _, loc := range subtitleTagRe.FindAllStringIndex(s, -1) {
+		b.text(s, last, loc[0])
+		b.add(loc[0], loc[1])
+		last = loc[1]
+	}
+	b.text(s, last, len(s))
+	return b
+}
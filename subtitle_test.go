@@ -0,0 +1,101 @@
+package gocn2an
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubtitleRoundTrip(t *testing.T) {
+	srt := "1\r\n00:00:01,000 --> 00:00:03,500\r\n<i>第一集</i>\r\n\r\n2\r\n00:00:04,000 --> 00:00:06,000\r\n{\\an8}两点半见\r\n"
+	sub, err := ReadSRT(strings.NewReader(srt))
+	if err != nil {
+		t.Fatalf("ReadSRT error: %v", err)
+	}
+	if len(sub.Cues) != 2 || sub.Cues[0].Start != time.Second || sub.Cues[0].End != 3500*time.Millisecond || sub.Cues[1].ID != "2" {
+		t.Errorf("ReadSRT = %+v", sub.Cues)
+	}
+	var builder strings.Builder
+	if _, err := sub.WriteTo(&builder); err != nil || builder.String() != srt {
+		t.Errorf("WriteTo = %q, %v, want %q", builder.String(), err, srt)
+	}
+
+	vtt := "WEBVTT - 字幕\n\nSTYLE\n::cue { color: red }\n\nintro\n01:02.500 --> 01:04.000 align:start\n<v 主持人>一百二十号</v>\n\nNOTE 结束\n"
+	sub, err = ReadWebVTT(strings.NewReader(vtt))
+	if err != nil {
+		t.Fatalf("ReadWebVTT error: %v", err)
+	}
+	if len(sub.Cues) != 1 || sub.Cues[0].ID != "intro" || sub.Cues[0].Start != 62500*time.Millisecond || len(sub.Cues[0].Notes) != 1 || len(sub.Notes) != 1 {
+		t.Errorf("ReadWebVTT = %+v", sub)
+	}
+	builder.Reset()
+	if _, err := sub.WriteTo(&builder); err != nil || builder.String() != vtt {
+		t.Errorf("WriteTo = %q, %v, want %q", builder.String(), err, vtt)
+	}
+
+	for _, item := range []struct {
+		input string
+		read  func(string) error
+	}{
+		{"1\n第一集\n", func(s string) error { _, err := ReadSRT(strings.NewReader(s)); return err }},
+		{"00:00:01.000 --> 00:00:02.000\n一\n", func(s string) error { _, err := ReadWebVTT(strings.NewReader(s)); return err }},
+	} {
+		if err := item.read(item.input); err == nil {
+			t.Errorf("read(%q) should return error", item.input)
+		}
+	}
+}
+
+func TestTransformSubtitle(t *testing.T) {
+	srt := "1\n00:00:01,000 --> 00:00:03,500\n<i>第一集</i>\n\n2\n00:00:04,000 --> 00:00:06,000\n{\\an8}两点半见\n共有一百二十个\n"
+	sub, _ := ReadSRT(strings.NewReader(srt))
+	transform := NewTransform()
+	if err := transform.TransformSubtitle(sub, "cn2an"); err != nil {
+		t.Fatalf("TransformSubtitle error: %v", err)
+	}
+	expected := "1\n00:00:01,000 --> 00:00:03,500\n<i>第1集</i>\n\n2\n00:00:04,000 --> 00:00:06,000\n{\\an8}2:30见\n共有120个\n"
+	var builder strings.Builder
+	sub.WriteTo(&builder)
+	if builder.String() != expected {
+		t.Errorf("TransformSubtitle = %q, want %q", builder.String(), expected)
+	}
+
+	vtt := "WEBVTT\n\n00:01.000 --> 00:02.000\n<c.yellow>3-2=1</c> <00:00:01.500>共12个\n"
+	sub, _ = ReadWebVTT(strings.NewReader(vtt))
+	if err := transform.TransformSubtitleWithAlignment(sub, "an2cn"); err != nil {
+		t.Fatalf("TransformSubtitleWithAlignment error: %v", err)
+	}
+	cue := sub.Cues[0]
+	if expected := "<c.yellow>三减二等于一</c> <00:00:01.500>共十二个"; cue.Text != expected {
+		t.Errorf("TransformSubtitleWithAlignment = %q, want %q", cue.Text, expected)
+	}
+	original := "<c.yellow>3-2=1</c> <00:00:01.500>共12个"
+	var changed []string
+	for _, span := range cue.Alignment {
+		if span.Changed {
+			changed = append(changed, original[span.SrcStart:span.SrcEnd]+"=>"+cue.Text[span.DstStart:span.DstEnd])
+		}
+	}
+	if got, want := strings.Join(changed, " "), "3=>三 -=>减 2=>二 ==>等于 1=>一 12=>十二"; got != want {
+		t.Errorf("TransformSubtitleWithAlignment alignment = %q, want %q", got, want)
+	}
+
+	// 严格模式下按字幕区分失败
+	srt = "1\n00:00:01,000 --> 00:00:02,000\n共99999999999999999999个\n\n2\n00:00:03,000 --> 00:00:04,000\n第3集，共99999999999999999999个\n"
+	sub, _ = ReadSRT(strings.NewReader(srt))
+	err := NewTransform(WithStrictErrors()).TransformSubtitle(sub, "an2cn")
+	var failures SubtitleErrors
+	if !errors.As(err, &failures) || len(failures) != 2 || failures[0].Cue != 0 || failures[1].Cue != 1 ||
+		failures[0].Err[0].Start != 3 || failures[1].Err[0].Start != 13 {
+		t.Errorf("TransformSubtitle(strict) error = %v, want failures in cue 0 at 3 and cue 1 at 13", err)
+	}
+	var conversion ConversionErrors
+	if !errors.As(err, &conversion) {
+		t.Errorf("TransformSubtitle(strict) error = %v, want to unwrap ConversionErrors", err)
+	}
+
+	if err := transform.TransformSubtitle(sub, "abc"); err == nil {
+		t.Error("TransformSubtitle with invalid method should return error")
+	}
+}
@@ -0,0 +1,280 @@
+package gocn2an
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TimeOfDay 一天中的时刻（24 小时制）
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// String 输出 15:04 或 15:04:05 形式
+func (t TimeOfDay) String() string {
+	if t.Second != 0 {
+		return fmt.Sprintf("%d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	}
+	return fmt.Sprintf("%d:%02d", t.Hour, t.Minute)
+}
+
+// On 返回 day 当天该时刻的 time.Time
+func (t TimeOfDay) On(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour, t.Minute, t.Second, 0, day.Location())
+}
+
+// timeModeList An2cnTime 支持的模式：24h(二十四小时制), 12h(带上午/下午等时段)
+var timeModeList = []string{"24h", "12h"}
+
+const (
+	timeNumPattern    = `[0-9零〇一二三四五六七八九十两兩廿]+`
+	timePeriodPattern = `凌晨|早上|早晨|清晨|上午|中午|午后|下午|傍晚|晚上|夜里|夜间|半夜`
+)
+
+var (
+	// 14:30、下午3:30:05
+	timeColonRe = regexp.MustCompile(fmt.Sprintf(`^(%s)?(\d{1,2})[:：](\d{2})(?:[:：](\d{2}))?$`, timePeriodPattern))
+	// 差五分十点
+	timeChaBeforeRe = regexp.MustCompile(fmt.Sprintf(`^(%s)?差(%s)分?[钟鐘]?(%s)[点點时時][钟鐘]?$`, timePeriodPattern, timeNumPattern, timeNumPattern))
+	// 十点差五分
+	timeChaAfterRe = regexp.MustCompile(fmt.Sprintf(`^(%s)?(%s)[点點时時][钟鐘]?差(%s)分?[钟鐘]?$`, timePeriodPattern, timeNumPattern, timeNumPattern))
+	// 三点一刻、两点半、十四点零五分、八点整、九点钟
+	timeMainRe = regexp.MustCompile(fmt.Sprintf(`^(%s)?(%s)[点點时時][钟鐘]?(?:(整)|(半)|(%s)刻|(%s)分?[钟鐘]?(?:(%s)秒)?)?$`,
+		timePeriodPattern, timeNumPattern, timeNumPattern, timeNumPattern, timeNumPattern))
+
+	// 句子中的时刻：需带有分、刻、半、整、钟、差或时段词，避免与小数混淆
+	timeTextRe = regexp.MustCompile(fmt.Sprintf(
+		`(?:%[1]s)?差%[2]s分?[钟鐘]?%[2]s[点點时時][钟鐘]?|(?:%[1]s)?%[2]s[点點时時][钟鐘]?差%[2]s分?[钟鐘]?|(?:%[1]s)%[2]s[点點时時][钟鐘]?(?:整|半|%[2]s刻|%[2]s分(?:%[2]s秒)?|%[2]s)?|%[2]s[点點时時](?:[钟鐘]|整|半|%[2]s刻|%[2]s分(?:%[2]s秒)?)`,
+		timePeriodPattern, timeNumPattern))
+	timeTextColonRe = regexp.MustCompile(`\d{1,2}[:：]\d{2}(?:[:：]\d{2})?`)
+)
+
+// ParseTimeOfDay 解析中文时刻，如 下午三点一刻、两点半、差五分十点、十四点零五分、14:30
+// 上午/下午/凌晨/晚上 等时段词会换算为 24 小时制
+func (c *Cn2An) ParseTimeOfDay(inputs string) (TimeOfDay, error) {
+	data := strings.TrimSpace(normalizeText(inputs))
+	if data == "" {
+		return TimeOfDay{}, errors.New("输入数据为空")
+	}
+
+	var period string
+	var hour, minute, second, before int
+	var err error
+
+	if subs := timeColonRe.FindStringSubmatch(data); subs != nil {
+		period = subs[1]
+		if hour, err = c.timeNumber(subs[2]); err != nil {
+			return TimeOfDay{}, err
+		}
+		if minute, err = c.timeNumber(subs[3]); err != nil {
+			return TimeOfDay{}, err
+		}
+		if subs[4] != "" {
+			if second, err = c.timeNumber(subs[4]); err != nil {
+				return TimeOfDay{}, err
+			}
+		}
+	} else if subs := timeChaBeforeRe.FindStringSubmatch(data); subs != nil {
+		period = subs[1]
+		if hour, before, err = c.timeBefore(subs[3], subs[2]); err != nil {
+			return TimeOfDay{}, err
+		}
+	} else if subs := timeChaAfterRe.FindStringSubmatch(data); subs != nil {
+		period = subs[1]
+		if hour, before, err = c.timeBefore(subs[2], subs[3]); err != nil {
+			return TimeOfDay{}, err
+		}
+	} else if subs := timeMainRe.FindStringSubmatch(data); subs != nil {
+		period = subs[1]
+		if hour, err = c.timeNumber(subs[2]); err != nil {
+			return TimeOfDay{}, err
+		}
+		switch {
+		case subs[4] != "":
+			minute = 30
+		case subs[5] != "":
+			quarters, err := c.timeNumber(subs[5])
+			if err != nil {
+				return TimeOfDay{}, err
+			}
+			if quarters > 3 {
+				return TimeOfDay{}, fmt.Errorf("不符合格式的时刻：%s", inputs)
+			}
+			minute = quarters * 15
+		case subs[6] != "":
+			if minute, err = c.timeNumber(subs[6]); err != nil {
+				return TimeOfDay{}, err
+			}
+			if subs[7] != "" {
+				if second, err = c.timeNumber(subs[7]); err != nil {
+					return TimeOfDay{}, err
+				}
+			}
+		}
+	} else {
+		return TimeOfDay{}, fmt.Errorf("不符合格式的时刻：%s", inputs)
+	}
+
+	hour, err = applyTimePeriod(period, hour)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	if before > 0 {
+		// 差五分十点 => 9:55，差五分零点 => 23:55
+		total := (hour*60 - before + 24*60) % (24 * 60)
+		hour, minute = total/60, total%60
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 || second < 0 || second > 59 {
+		return TimeOfDay{}, fmt.Errorf("时刻超出范围：%s", inputs)
+	}
+
+	return TimeOfDay{Hour: hour, Minute: minute, Second: second}, nil
+}
+
+// timeNumber 解析时刻中的整数
+func (c *Cn2An) timeNumber(s string) (int, error) {
+	val, err := c.Cn2an(s, "smart")
+	if err != nil {
+		return 0, err
+	}
+	if val != float64(int(val)) {
+		return 0, fmt.Errorf("时刻中的数字必须为整数：%s", s)
+	}
+	return int(val), nil
+}
+
+// timeBefore 解析「差几分几点」，返回整点及相差的分钟数
+func (c *Cn2An) timeBefore(hourStr, minuteStr string) (int, int, error) {
+	hour, err := c.timeNumber(hourStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	diff, err := c.timeNumber(minuteStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if diff <= 0 || diff >= 60 {
+		return 0, 0, fmt.Errorf("时刻超出范围：差%d分", diff)
+	}
+	return hour, diff, nil
+}
+
+// applyTimePeriod 根据时段词换算为 24 小时制
+func applyTimePeriod(period string, hour int) (int, error) {
+	if hour == 24 && period == "" {
+		return 0, nil
+	}
+	switch period {
+	case "":
+		return hour, nil
+	case "凌晨", "半夜":
+		if hour == 12 {
+			return 0, nil
+		}
+		return hour, nil
+	case "早上", "早晨", "清晨", "上午":
+		return hour, nil
+	case "中午":
+		if hour >= 1 && hour <= 3 {
+			return hour + 12, nil
+		}
+		return hour, nil
+	case "午后", "下午", "傍晚", "晚上", "夜里", "夜间":
+		if hour == 12 && (period == "晚上" || period == "夜里" || period == "夜间") {
+			return 0, nil
+		}
+		if hour >= 1 && hour < 12 {
+			return hour + 12, nil
+		}
+		return hour, nil
+	}
+	return 0, fmt.Errorf("不支持的时段：%s", period)
+}
+
+// An2cnTime 将 time.Time 的时刻转为中文
+// mode: 24h(十四点零五分), 12h(下午两点零五分)
+func (a *An2Cn) An2cnTime(t time.Time, mode string) (string, error) {
+	return a.formatTimeOfDay(TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}, mode)
+}
+
+// formatTimeOfDay 将时刻转为中文
+func (a *An2Cn) formatTimeOfDay(t TimeOfDay, mode string) (string, error) {
+	if !contains(timeModeList, mode) {
+		return "", fmt.Errorf("mode 仅支持 %v", timeModeList)
+	}
+	if t.Hour < 0 || t.Hour > 23 || t.Minute < 0 || t.Minute > 59 || t.Second < 0 || t.Second > 59 {
+		return "", fmt.Errorf("时刻超出范围：%s", t)
+	}
+
+	var builder strings.Builder
+	hour := t.Hour
+	if mode == "12h" {
+		builder.WriteString(timePeriodOf(hour))
+		if hour > 12 {
+			hour -= 12
+		} else if hour == 0 {
+			hour = 12
+		}
+	}
+
+	if hour == 2 {
+		builder.WriteString("两")
+	} else {
+		hourStr, err := a.An2cn(hour, "low")
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(hourStr)
+	}
+	builder.WriteString("点")
+
+	if t.Minute != 0 || t.Second != 0 {
+		minuteStr, err := a.clockField(t.Minute)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(minuteStr + "分")
+	}
+	if t.Second != 0 {
+		secondStr, err := a.clockField(t.Second)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(secondStr + "秒")
+	}
+
+	return builder.String(), nil
+}
+
+// clockField 输出分、秒数值，个位数补「零」
+func (a *An2Cn) clockField(val int) (string, error) {
+	str, err := a.An2cn(val, "low")
+	if err != nil {
+		return "", err
+	}
+	if val < 10 && val != 0 {
+		str = "零" + str
+	}
+	return str, nil
+}
+
+// timePeriodOf 返回 24 小时制小时对应的时段词
+func timePeriodOf(hour int) string {
+	switch {
+	case hour < 6:
+		return "凌晨"
+	case hour < 12:
+		return "上午"
+	case hour == 12:
+		return "中午"
+	case hour < 18:
+		return "下午"
+	default:
+		return "晚上"
+	}
+}
@@ -0,0 +1,97 @@
+package gocn2an
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	testData := map[string]TimeOfDay{
+		"三点一刻":      {Hour: 3, Minute: 15},
+		"三点三刻":      {Hour: 3, Minute: 45},
+		"两点半":       {Hour: 2, Minute: 30},
+		"兩點半":       {Hour: 2, Minute: 30},
+		"差五分十点":     {Hour: 9, Minute: 55},
+		"十点差五分":     {Hour: 9, Minute: 55},
+		"差五分零点":     {Hour: 23, Minute: 55},
+		"十四点零五分":    {Hour: 14, Minute: 5},
+		"十四点零五分三十秒": {Hour: 14, Minute: 5, Second: 30},
+		"八点整":       {Hour: 8},
+		"九点钟":       {Hour: 9},
+		"14:30":     {Hour: 14, Minute: 30},
+		"14：30：05":  {Hour: 14, Minute: 30, Second: 5},
+		"下午三点一刻":    {Hour: 15, Minute: 15},
+		"下午3:30":    {Hour: 15, Minute: 30},
+		"下午差五分一点":   {Hour: 12, Minute: 55},
+		"凌晨十二点":     {Hour: 0},
+		"凌晨三点":      {Hour: 3},
+		"上午十点":      {Hour: 10},
+		"中午十二点":     {Hour: 12},
+		"中午一点":      {Hour: 13},
+		"晚上八点半":     {Hour: 20, Minute: 30},
+		"晚上十二点":     {Hour: 0},
+		"二十四点":      {Hour: 0},
+	}
+
+	c := NewCn2An()
+	for input, expected := range testData {
+		result, err := c.ParseTimeOfDay(input)
+		if err != nil {
+			t.Errorf("ParseTimeOfDay(%q) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("ParseTimeOfDay(%q) = %v, want %v", input, result, expected)
+		}
+	}
+
+	for _, input := range []string{"", "三点五刻", "二十五点", "十点六十分", "差六十分十点", "下雨了"} {
+		if _, err := c.ParseTimeOfDay(input); err == nil {
+			t.Errorf("ParseTimeOfDay(%q) should return error but got nil", input)
+		}
+	}
+}
+
+func TestAn2cnTime(t *testing.T) {
+	testData := []struct {
+		hour, minute, second int
+		mode                 string
+		expected             string
+	}{
+		{14, 5, 0, "24h", "十四点零五分"},
+		{14, 30, 0, "24h", "十四点三十分"},
+		{2, 0, 0, "24h", "两点"},
+		{9, 5, 30, "24h", "九点零五分三十秒"},
+		{14, 5, 0, "12h", "下午两点零五分"},
+		{0, 30, 0, "12h", "凌晨十二点三十分"},
+		{12, 0, 0, "12h", "中午十二点"},
+		{20, 15, 0, "12h", "晚上八点十五分"},
+		{10, 0, 0, "12h", "上午十点"},
+	}
+
+	a := NewAn2Cn()
+	for _, item := range testData {
+		input := time.Date(2024, 5, 1, item.hour, item.minute, item.second, 0, time.UTC)
+		result, err := a.An2cnTime(input, item.mode)
+		if err != nil {
+			t.Errorf("An2cnTime(%v, %s) error: %v", input, item.mode, err)
+			continue
+		}
+		if result != item.expected {
+			t.Errorf("An2cnTime(%v, %s) = %s, want %s", input, item.mode, result, item.expected)
+		}
+	}
+
+	if _, err := a.An2cnTime(time.Now(), "ampm"); err == nil {
+		t.Errorf("An2cnTime with mode %q should return error but got nil", "ampm")
+	}
+}
+
+func TestTimeOfDayOn(t *testing.T) {
+	day := time.Date(2024, 5, 1, 23, 59, 59, 0, time.UTC)
+	result := TimeOfDay{Hour: 15, Minute: 15}.On(day)
+	expected := time.Date(2024, 5, 1, 15, 15, 0, 0, time.UTC)
+	if !result.Equal(expected) {
+		t.Errorf("On(%v) = %v, want %v", day, result, expected)
+	}
+}
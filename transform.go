@@ -3,9 +3,12 @@ package gocn2an
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Transform 句子转换器
@@ -13,22 +16,89 @@ type Transform struct {
 	allNum                 string
 	allUnit                string
 	cn2an                  *Cn2An
+	cn2anOptions           []Cn2AnOption
 	an2cn                  *An2Cn
 	cnPattern              string
 	smartCnPattern         string
 	cnPatternRe            *regexp.Regexp
 	smartCnPatternRe       *regexp.Regexp
+	cnUpperPatternRe       *regexp.Regexp
+	cantoneseClockRe       *regexp.Regexp
+	slangRe                *regexp.Regexp
+	slang                  bool
 	mathSymbolReplacer     *strings.Replacer
+	mathSymbolPairs        []string
 	binaryMinusPlaceholder string
+	ganzhiReference        int
+	relativeReference      time.Time
+	lexicon                map[string]lexiconEntry
+	termMatcher            *termMatcher
+	segmenter              Segmenter
+	cn2anStages            []transformStage
+	an2cnStages            []transformStage
+	strict                 bool
+	warningHandler         func(ConversionError)
 }
 
+// TransformOption 句子转换器选项
+type TransformOption func(*Transform)
+
+// WithGanzhiAnnotation 在 cn2an 句子转换中为干支纪年标注公历年份，如 甲辰年 => 甲辰年（2024）；
+// referenceYear 为推断年份的参考年，为 0 时取当前年份
+func WithGanzhiAnnotation(referenceYear int) TransformOption {
+	return func(t *Transform) {
+		if referenceYear == 0 {
+			referenceYear = time.Now().Year()
+		}
+		t.ganzhiReference = referenceYear
+	}
+}
+
+// WithRelativeDates 在 cn2an 句子转换中以 reference 为基准，将 明天下午三点、下周三 等相对日期改写为 ISO 日期
+func WithRelativeDates(reference time.Time) TransformOption {
+	return func(t *Transform) {
+		t.relativeReference = reference
+	}
+}
+
+// WithSlangMagnitudes 在 cn2an 句子转换中识别 5w、1.2kw、5 million 等数量级写法；
+// 默认关闭，避免误伤 1.8m、4K 等单位
+func WithSlangMagnitudes() TransformOption {
+	return func(t *Transform) {
+		t.slang = true
+	}
+}
+
+// WithCn2AnOptions 以 opts 创建句子转换使用的 Cn2An，如 WithSlangUnit("lakh", 100000)
+func WithCn2AnOptions(opts ...Cn2AnOption) TransformOption {
+	return func(t *Transform) {
+		t.cn2anOptions = append(t.cn2anOptions, opts...)
+	}
+}
+
+// measureWordPattern 可跟在「两」「半」后的常用量词和单位
+const measureWordPattern = `个|個|只|隻|件|位|名|人|口|次|回|遍|趟|块|塊|元|角|毛|岁|歲|斤|公斤|千克|克|吨|噸|` +
+	`公里|千米|米|厘米|毫米|里|升|毫升|杯|碗|瓶|盒|包|袋|箱|张|張|本|页|頁|条|條|根|支|种|種|家|层|層|` +
+	`台|辆|輛|份|颗|顆|圈|场|場|届|屆|年|月|周|週|天|日|小时|小時|分钟|分鐘|分|秒`
+
+// multipleWordPattern 只跟在「两」后的倍数、成数和量词；半成品、半双工 中的「半」不是数字
+const multipleWordPattern = `倍|成|双|雙|对|對|级|級|项|項|套|座|栋|棟|间|間|把|排|行|片|段|节|節|门|門|部|篇|首|幅|副|组|組`
+
+var (
+	// halfRe 数字中的「半」：半斤、三块半、两个半；上半年、下半年 保持原样
+	halfRe = regexp.MustCompile(fmt.Sprintf(`[上下]半年|(%[1]s)(%[2]s)半|半(%[2]s)`, durationNumPattern, measureWordPattern))
+	// measureWordPrefixRe 以量词开头
+	measureWordPrefixRe = regexp.MustCompile(fmt.Sprintf(`^(?:%s|%s)`, measureWordPattern, multipleWordPattern))
+)
+
+var ganzhiYearRe = regexp.MustCompile(`[甲乙丙丁戊己庚辛壬癸][子丑寅卯辰巳午未申酉戌亥]年`)
+
 var exponentPattern = regexp.MustCompile(`([^\s\^]+)\s*\^\s*([^\s\^]+)`)
 
 // NewTransform 创建新的句子转换器
-func NewTransform() *Transform {
+func NewTransform(opts ...TransformOption) *Transform {
 	t := &Transform{
-		allNum: "零一二三四五六七八九",
-		cn2an:  NewCn2An(),
+		allNum: "零〇一二三四五六七八九两",
 		an2cn:  NewAn2Cn(),
 	}
 
@@ -36,11 +106,26 @@ func NewTransform() *Transform {
 		t.allUnit += string(r)
 	}
 
-	t.cnPattern = fmt.Sprintf(`负?([%s%s]+点)?[%s%s]+`, t.allNum, t.allUnit, t.allNum, t.allUnit)
+	// 繁体及兼容字形：兩、萬、億 等；合文：廿、卅、卌、皕
+	t.allNum += variantRunesOf("零一二三四五六七八九两")
+	for r := range ContractionCN2AN {
+		t.allNum += string(r)
+	}
+	t.allUnit += variantRunesOf(t.allUnit)
+
+	t.cnPattern = fmt.Sprintf(`[负負]?([%s%s]+[点點])?[%s%s]+`, t.allNum, t.allUnit, t.allNum, t.allUnit)
 	t.smartCnPattern = fmt.Sprintf(`-?([0-9]+.)?[0-9]+[%s]+`, t.allUnit)
 	t.cnPatternRe = regexp.MustCompile(t.cnPattern)
 	t.smartCnPatternRe = regexp.MustCompile(t.smartCnPattern)
 
+	t.cantoneseClockRe = regexp.MustCompile(fmt.Sprintf(`([%s%s]+)([点點])([%s%s]+)[個个]字`, t.allNum, t.allUnit, t.allNum, t.allUnit))
+
+	// 大写数字必须与单位相连，避免误伤「大陆」等词语；至少含一个壹至玖，避免 一百零一 中的 百零 被当作大写数字
+	upperDigit := "壹贰叁肆伍陆柒捌玖" + variantRunesOf("贰叁陆")
+	upperNum := "零〇" + upperDigit
+	t.cnUpperPatternRe = regexp.MustCompile(fmt.Sprintf(`[负負]?(?:[%s]*[%s][%s]*[%s]|[%s][零〇]*[%s])[%s%s]*`,
+		upperNum, upperDigit, upperNum, t.allUnit, t.allUnit, upperDigit, upperNum, t.allUnit))
+
 	mathPairs := []string{
 		"<=", "小于等于",
 		">=", "大于等于",
@@ -81,88 +166,188 @@ func NewTransform() *Transform {
 		"∂", "偏导",
 	}
 	t.mathSymbolReplacer = strings.NewReplacer(mathPairs...)
+	t.mathSymbolPairs = mathPairs
 	t.binaryMinusPlaceholder = "@@__CNAN_MINUS__@@"
+	t.lexicon = builtinLexicon()
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	t.cn2an = NewCn2An(t.cn2anOptions...)
+	t.slangRe = regexp.MustCompile(`\b` + t.cn2an.slangSuffixPattern() + `\b`)
+
+	terms := make([]string, 0, len(t.lexicon))
+	for term := range t.lexicon {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	t.termMatcher = newTermMatcher(terms)
+	t.cn2anStages = t.buildCn2anStages()
+	t.an2cnStages = t.buildAn2cnStages()
 
 	return t
 }
 
-// Transform 转换句子中的数字
-// inputs: 输入句子
-// method: cn2an(中文转阿拉伯) 或 an2cn(阿拉伯转中文)
-func (t *Transform) Transform(inputs, method string) (string, error) {
-	if method == "cn2an" {
-		inputs = strings.ReplaceAll(inputs, "廿", "二十")
-		inputs = strings.ReplaceAll(inputs, "半", "0.5")
-		inputs = strings.ReplaceAll(inputs, "两", "2")
+// transformStage 句子转换中的一个识别阶段，各阶段按顺序在上一阶段的结果上替换
+type transformStage struct {
+	subMode  string
+	category string
+	re       *regexp.Regexp
+	// accept 为 nil 或返回 true 时才替换 s[start:end]
+	accept func(s string, start, end int) bool
+}
 
-		// 日期
-		datePattern := fmt.Sprintf(`(((%s)|(%s))年)?([%s十]+月)?([%s十]+日)?`, t.smartCnPattern, t.cnPattern, t.allNum, t.allNum)
-		dateRe := regexp.MustCompile(datePattern)
-		inputs = dateRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "cn2an", "date")
-		})
+// buildCn2anStages 按顺序构建 cn2an 识别阶段
+func (t *Transform) buildCn2anStages() []transformStage {
+	var stages []transformStage
 
-		// 分数
-		fractionPattern := fmt.Sprintf(`%s分之%s`, t.cnPattern, t.cnPattern)
-		fractionRe := regexp.MustCompile(fractionPattern)
-		inputs = fractionRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "cn2an", "fraction")
-		})
+	// 相对日期
+	if !t.relativeReference.IsZero() {
+		stages = append(stages, transformStage{subMode: "relative_date", category: "date", re: relativeTextRe, accept: t.isRelativeDate})
+	}
 
-		// 百分比
-		percentPattern := fmt.Sprintf(`百分之%s`, t.cnPattern)
-		percentRe := regexp.MustCompile(percentPattern)
-		inputs = percentRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "cn2an", "percent")
-		})
+	// 干支纪年
+	if t.ganzhiReference != 0 {
+		stages = append(stages, transformStage{subMode: "ganzhi", category: "date", re: ganzhiYearRe})
+	}
 
-		// 摄氏度
-		celsiusPattern := fmt.Sprintf(`%s摄氏度`, t.cnPattern)
-		celsiusRe := regexp.MustCompile(celsiusPattern)
-		inputs = celsiusRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "cn2an", "celsius")
-		})
+	datePattern := fmt.Sprintf(`(((%s)|(%s))年)?([%s十]+月)?([%s十]+日)?`, t.smartCnPattern, t.cnPattern, t.allNum, t.allNum)
 
-		// 数字
-		output := t.cnPatternRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "cn2an", "number")
-		})
+	stages = append(stages,
+		// 时刻：需在「半」「两」之前，避免 两点半 => 2点0.5
+		transformStage{subMode: "time", category: "time", re: timeTextRe},
+		// 时长：一个半小时 => 1.5小时、三天半 => 3.5天
+		transformStage{subMode: "duration", category: "duration", re: durationTextRe},
+		// 农历日期：正月初一 => 农历1月1日
+		transformStage{subMode: "lunar", category: "lunar_date", re: lunarTextRe},
+		// 粤语报时：三點三個字 => 3點15分
+		transformStage{subMode: "cantonese_clock", category: "time", re: t.cantoneseClockRe},
+		// 季度：第三季度 => Q3
+		transformStage{subMode: "quarter", category: "quarter", re: quarterTextRe},
+		// 半：半斤 => 0.5斤、三块半 => 3.5块，半导体 等词语保持原样
+		transformStage{subMode: "half", category: "cardinal", re: halfRe},
+	)
 
-		return output, nil
-	} else if method == "an2cn" {
-		inputs = t.preprocessAn2cnMathSymbols(inputs)
+	// 网络用语数量级：5w、1.2kw、5 million
+	if t.slang {
+		stages = append(stages, transformStage{subMode: "number", category: "cardinal", re: t.slangRe})
+	}
 
+	stages = append(stages,
 		// 日期
-		dateRe := regexp.MustCompile(`(?:\d{2,4}\s*年\s*(?:\d{1,2}\s*月\s*)?(?:\d{1,2}\s*日)?)|(?:\d{1,2}\s*月\s*(?:\d{1,2}\s*日)?)|(?:\d{1,2}\s*日)`)
-		inputs = dateRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "an2cn", "date")
-		})
-
+		transformStage{subMode: "date", category: "date", re: regexp.MustCompile(datePattern)},
 		// 分数
-		fractionRe := regexp.MustCompile(`\d+/\d+`)
-		inputs = fractionRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "an2cn", "fraction")
-		})
-
+		transformStage{subMode: "fraction", category: "fraction", re: regexp.MustCompile(fmt.Sprintf(`%s分之%s`, t.cnPattern, t.cnPattern))},
 		// 百分比
-		percentRe := regexp.MustCompile(`-?(\d+\.)?\d+%`)
-		inputs = percentRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "an2cn", "percent")
-		})
-
+		transformStage{subMode: "percent", category: "percent", re: regexp.MustCompile(fmt.Sprintf(`百分之%s`, t.cnPattern))},
 		// 摄氏度
-		celsiusRe := regexp.MustCompile(`\d+℃`)
-		inputs = celsiusRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "an2cn", "celsius")
-		})
+		transformStage{subMode: "celsius", category: "celsius", re: regexp.MustCompile(fmt.Sprintf(`%s摄氏度`, t.cnPattern))},
+		// 大写数字
+		transformStage{subMode: "number", category: "cardinal", re: t.cnUpperPatternRe, accept: t.isCnUpperNumber},
+		// 数字
+		transformStage{subMode: "number", category: "cardinal", re: t.cnPatternRe, accept: isCnNumber},
+	)
+	return stages
+}
 
+var (
+	an2cnDateRe     = regexp.MustCompile(`(?:\d{2,4}\s*年\s*(?:\d{1,2}\s*月\s*)?(?:\d{1,2}\s*日)?)|(?:\d{1,2}\s*月\s*(?:\d{1,2}\s*日)?)|(?:\d{1,2}\s*日)`)
+	an2cnFractionRe = regexp.MustCompile(`\d+/\d+`)
+	an2cnPercentRe  = regexp.MustCompile(`-?(\d+\.)?\d+%`)
+	an2cnCelsiusRe  = regexp.MustCompile(`\d+℃`)
+	an2cnNumberRe   = regexp.MustCompile(`-?(\d+\.)?\d+`)
+)
+
+// buildAn2cnStages 按顺序构建 an2cn 识别阶段
+func (t *Transform) buildAn2cnStages() []transformStage {
+	return []transformStage{
+		// 季度、财年和年代：Q3 => 第三季度、2024财年 => 二零二四财年、80年代 => 八十年代
+		{subMode: "date_range", category: "date", re: dateRangeAn2cnRe},
+		// 日期
+		{subMode: "date", category: "date", re: an2cnDateRe},
+		// 分数
+		{subMode: "fraction", category: "fraction", re: an2cnFractionRe},
+		// 百分比
+		{subMode: "percent", category: "percent", re: an2cnPercentRe},
+		// 摄氏度
+		{subMode: "celsius", category: "celsius", re: an2cnCelsiusRe},
 		// 数字
-		numberRe := regexp.MustCompile(`-?(\d+\.)?\d+`)
-		output := numberRe.ReplaceAllStringFunc(inputs, func(match string) string {
-			return t.subUtil(match, "an2cn", "number")
-		})
+		{subMode: "number", category: "cardinal", re: an2cnNumberRe},
+	}
+}
 
-		output = t.postprocessAn2cnMathSymbols(output)
+// applyStage 执行一个识别阶段，改写位置和未能转换的片段记录到 rec
+func (t *Transform) applyStage(s, method string, stage transformStage, rec *alignmentRecorder) string {
+	var builder strings.Builder
+	var edits []textEdit
+	last := 0
+	for _, loc := range stage.re.FindAllStringIndex(s, -1) {
+		if loc[0] == loc[1] || stage.accept != nil && !stage.accept(s, loc[0], loc[1]) {
+			continue
+		}
+		output, err := t.subUtil(s[loc[0]:loc[1]], method, stage.subMode)
+		if err != nil {
+			rec.fail(loc[0], loc[1], stage.category, err)
+		}
+		if output == s[loc[0]:loc[1]] {
+			continue
+		}
+		builder.WriteString(s[last:loc[0]])
+		builder.WriteString(output)
+		edits = append(edits, textEdit{start: loc[0], end: loc[1], text: output})
+		last = loc[1]
+	}
+	if edits == nil {
+		return s
+	}
+	builder.WriteString(s[last:])
+	result := builder.String()
+	rec.record(result, edits)
+	return result
+}
+
+// Transform 转换句子中的数字
+// inputs: 输入句子
+// method: cn2an(中文转阿拉伯) 或 an2cn(阿拉伯转中文)
+func (t *Transform) Transform(inputs, method string) (string, error) {
+	if !t.reportsFailures() {
+		return t.transform(inputs, method, nil)
+	}
+	output, _, failures, err := t.transformWithFailures(inputs, method)
+	if err != nil {
+		return "", err
+	}
+	return output, t.reportFailures(failures)
+}
+
+// transform 执行句子转换，rec 不为 nil 时记录每一步的中间结果
+func (t *Transform) transform(inputs, method string, rec *alignmentRecorder) (string, error) {
+	folded, offsets := foldNumericFormsWithOffsets(inputs, false, true)
+	if rec != nil {
+		rec.record(folded, offsetEdits(inputs, folded, offsets))
+	}
+	inputs = folded
+
+	if method == "cn2an" {
+		// 成语、地名、品牌等词语及分词器判定为非数词的词语不做转换：一心一意、四川、三星
+		inputs, protected := t.protectTerms(inputs, rec)
+
+		for _, stage := range t.cn2anStages {
+			inputs = t.applyStage(inputs, method, stage, rec)
+		}
+		return restoreTerms(inputs, protected, rec), nil
+	} else if method == "an2cn" {
+		inputs = t.preprocessAn2cnMathSymbols(inputs, rec)
+
+		// 时刻：14:30 => 十四点三十分
+		inputs = t.replaceClockTimes(inputs, rec)
+
+		for _, stage := range t.an2cnStages {
+			inputs = t.applyStage(inputs, method, stage, rec)
+		}
+
+		output := t.postprocessAn2cnMathSymbols(inputs, rec)
 
 		return output, nil
 	}
@@ -170,15 +355,17 @@ func (t *Transform) Transform(inputs, method string) (string, error) {
 	return "", fmt.Errorf("error method: %s, only support 'cn2an' and 'an2cn'", method)
 }
 
-// subUtil 替换辅助函数
-func (t *Transform) subUtil(inputs, method, subMode string) string {
+// subUtil 替换辅助函数；err 不为 nil 时为未能转换的数字（包括转换中的 panic），output 与不报告错误时相同：
+// 通常为原始输入，分数、日期中只有部分数字未能转换时为部分转换的结果。
+// 时刻、时长等识别阶段不符合写法时只返回原始输入，交由后续阶段处理
+func (t *Transform) subUtil(inputs, method, subMode string) (output string, err error) {
 	if inputs == "" {
-		return inputs
+		return inputs, nil
 	}
 
 	defer func() {
 		if r := recover(); r != nil {
-			// 发生错误时返回原始输入
+			output, err = inputs, fmt.Errorf("转换出错：%v", r)
 		}
 	}()
 
@@ -195,61 +382,217 @@ func (t *Transform) subUtil(inputs, method, subMode string) string {
 				}
 				// 转换为整数字符串
 				return fmt.Sprintf("%.0f", result)
-			})
+			}), nil
 
 		case "fraction":
 			if strings.HasPrefix(inputs, "百") {
-				return inputs
+				return inputs, nil
 			}
+			var convErr error
 			result := t.cnPatternRe.ReplaceAllStringFunc(inputs, func(match string) string {
 				val, err := t.cn2an.Cn2an(match, "smart")
 				if err != nil {
+					convErr = err
 					return match
 				}
 				return fmt.Sprintf("%.0f", val)
 			})
 			parts := strings.Split(result, "分之")
 			if len(parts) == 2 {
-				return fmt.Sprintf("%s/%s", parts[1], parts[0])
+				return fmt.Sprintf("%s/%s", parts[1], parts[0]), convErr
 			}
-			return inputs
+			return inputs, convErr
 
 		case "percent":
 			if !strings.HasPrefix(inputs, "百分之") {
-				return inputs
+				return inputs, nil
 			}
 			target := strings.TrimPrefix(inputs, "百分之")
 			val, err := t.cn2an.Cn2an(target, "smart")
 			if err != nil {
-				return inputs
+				return inputs, err
 			}
-			return strconv.FormatFloat(val, 'f', -1, 64) + "%"
+			return strconv.FormatFloat(val, 'f', -1, 64) + "%", nil
 
 		case "celsius":
 			if !strings.HasSuffix(inputs, "摄氏度") {
-				return inputs
+				return inputs, nil
 			}
 			target := strings.TrimSuffix(inputs, "摄氏度")
 			val, err := t.cn2an.Cn2an(target, "smart")
 			if err != nil {
-				return inputs
+				return inputs, err
 			}
-			return strconv.FormatFloat(val, 'f', -1, 64) + "℃"
+			return strconv.FormatFloat(val, 'f', -1, 64) + "℃", nil
+
+		case "time":
+			// 三点五分 等无时段词、分钟为个位数且不带「零」的写法更可能是小数，保持原样
+			if subs := timeMainRe.FindStringSubmatch(normalizeText(inputs)); subs != nil &&
+				subs[1] == "" && subs[6] != "" && subs[7] == "" && !strings.ContainsAny(subs[6], "零〇0十") && len([]rune(subs[6])) == 1 {
+				return inputs, nil
+			}
+			tod, err := t.cn2an.ParseTimeOfDay(inputs)
+			if err != nil {
+				return inputs, nil
+			}
+			return tod.String(), nil
+
+		case "duration":
+			subs := durationPartRe.FindStringSubmatch(inputs)
+			if subs == nil || subs[0] != inputs || subs[2] != "" && subs[5] != "" {
+				return inputs, nil
+			}
+			if subs[3] != "" && subs[4] == "夜" {
+				// 半夜 指深夜
+				return inputs, nil
+			}
+			half := subs[2] != "" || subs[3] != "" || subs[5] != ""
+			if !half && strings.Trim(subs[1], "0123456789.") == "" {
+				// 已是阿拉伯数字，如 3天、2024-06-05天
+				return inputs, nil
+			}
+			val := 0.0
+			if subs[1] != "" {
+				num, err := t.cn2an.Cn2an(subs[1], "smart")
+				if err != nil {
+					return inputs, nil
+				}
+				val = num
+			}
+			if half {
+				val += 0.5
+			}
+			// 三个小时 保留量词，一个半小时 => 1.5小时
+			unit := subs[4]
+			if measure := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(inputs, "半"), unit), "半"); strings.HasSuffix(measure, "个") || strings.HasSuffix(measure, "個") {
+				if !half || unit == "月" {
+					_, size := utf8.DecodeLastRuneInString(measure)
+					unit = measure[len(measure)-size:] + unit
+				}
+			}
+			return strconv.FormatFloat(val, 'f', -1, 64) + unit, nil
+
+		case "relative_date":
+			// 末尾的时刻无法解析时，只改写前面的日期部分
+			runes := []rune(inputs)
+			for i := len(runes); i > 0; i-- {
+				d, err := t.cn2an.ResolveRelativeDate(string(runes[:i]), t.relativeReference)
+				if err == nil {
+					return FormatISODate(d) + string(runes[i:]), nil
+				}
+			}
+			return inputs, nil
+
+		case "ganzhi":
+			reference := time.Date(t.ganzhiReference, 1, 1, 0, 0, 0, 0, time.UTC)
+			year, err := t.cn2an.Ganzhi2an(inputs, WithDateReference(reference))
+			if err != nil {
+				return inputs, nil
+			}
+			return fmt.Sprintf("%s（%d）", inputs, year), nil
+
+		case "lunar":
+			// 需有农历前缀、闰月、正/冬/腊月或 初一 等农历写法；廿、卅 在公历日期中同样常见
+			subs := lunarDateRe.FindStringSubmatch(normalizeText(inputs))
+			if subs == nil {
+				return inputs, nil
+			}
+			isLunar := subs[1] != "" || subs[3] != "" || strings.ContainsAny(subs[4], "正冬腊臘") ||
+				strings.HasPrefix(subs[5], "初")
+			if !isLunar {
+				return inputs, nil
+			}
+			var d LunarDate
+			if subs[2] != "" {
+				parsed, err := t.cn2an.ParseLunarDate(inputs)
+				if err != nil {
+					return inputs, nil
+				}
+				d = parsed
+			} else {
+				// 未写年份时只校验月、日范围
+				month, ok := lunarMonthAlias[subs[4]]
+				if !ok {
+					val, err := t.cn2an.timeNumber(subs[4])
+					if err != nil {
+						return inputs, nil
+					}
+					month = val
+				}
+				day, err := t.cn2an.timeNumber(strings.TrimPrefix(subs[5], "初"))
+				if err != nil || month < 1 || month > 12 || day < 1 || day > 30 {
+					return inputs, nil
+				}
+				d = LunarDate{Month: month, Day: day, Leap: subs[3] != ""}
+			}
+
+			var builder strings.Builder
+			builder.WriteString("农历")
+			if d.Year != 0 {
+				builder.WriteString(strconv.Itoa(d.Year) + "年")
+			}
+			if d.Leap {
+				builder.WriteString("闰")
+			}
+			builder.WriteString(fmt.Sprintf("%d月%d日", d.Month, d.Day))
+			return builder.String(), nil
+
+		case "quarter":
+			quarter, err := t.cn2an.timeNumber(strings.TrimSuffix(strings.TrimPrefix(inputs, "第"), "季度"))
+			if err != nil {
+				return inputs, nil
+			}
+			return fmt.Sprintf("Q%d", quarter), nil
+
+		case "half":
+			subs := halfRe.FindStringSubmatch(inputs)
+			if subs[1] == "" && subs[3] == "" {
+				return inputs, nil
+			}
+			if subs[3] != "" {
+				return "0.5" + subs[3], nil
+			}
+			val, err := t.cn2an.Cn2an(subs[1], "smart")
+			if err != nil {
+				return inputs, nil
+			}
+			return strconv.FormatFloat(val+0.5, 'f', -1, 64) + subs[2], nil
+
+		case "cantonese_clock":
+			// 一個字為五分鐘
+			subs := t.cantoneseClockRe.FindStringSubmatch(inputs)
+			if len(subs) != 4 {
+				return inputs, nil
+			}
+			hour, err := t.cn2an.Cn2an(subs[1], "smart")
+			if err != nil {
+				return inputs, nil
+			}
+			count, err := t.cn2an.Cn2an(subs[3], "smart")
+			if err != nil || count < 1 || count > 11 {
+				return inputs, nil
+			}
+			return fmt.Sprintf("%.0f%s%.0f分", hour, subs[2], count*5), nil
 
 		case "number":
 			val, err := t.cn2an.Cn2an(inputs, "smart")
+			if err != nil && strings.Trim(inputs, t.allUnit) == "" {
+				// 只有单位的 万岁、亿万 等不是数字
+				return inputs, nil
+			}
 			if err != nil {
-				return inputs
+				return inputs, err
 			}
-			return strconv.FormatFloat(val, 'f', -1, 64)
+			return strconv.FormatFloat(val, 'f', -1, 64), nil
 		}
 	} else if method == "an2cn" {
 		switch subMode {
 		case "date":
 			normalized := strings.Join(strings.Fields(inputs), "")
 			if normalized == "" {
-				return inputs
+				return inputs, nil
 			}
+			var convErr error
 			yearRe := regexp.MustCompile(`\d+年`)
 			result := yearRe.ReplaceAllStringFunc(normalized, func(match string) string {
 				digits := strings.TrimSuffix(match, "年")
@@ -258,41 +601,65 @@ func (t *Transform) subUtil(inputs, method, subMode string) string {
 				}
 				val, err := t.an2cn.An2cn(digits, "direct")
 				if err != nil {
+					convErr = err
 					return match
 				}
 				return val + "年"
 			})
 			// 月日用 low 模式
 			numRe := regexp.MustCompile(`\d+`)
-			return numRe.ReplaceAllStringFunc(result, func(match string) string {
+			result = numRe.ReplaceAllStringFunc(result, func(match string) string {
 				result, err := t.an2cn.An2cn(match, "low")
 				if err != nil {
+					convErr = err
 					return match
 				}
 				return result
 			})
+			return result, convErr
+
+		case "date_range":
+			subs := dateRangeAn2cnRe.FindStringSubmatch(inputs)
+			if subs[1] != "" {
+				quarter, _ := strconv.Atoi(subs[1])
+				return "第" + NumberLowAN2CN[quarter] + "季度", nil
+			}
+			if subs[4] != "" {
+				decade, err := t.an2cn.An2cn(subs[4], "low")
+				if err != nil {
+					return inputs, err
+				}
+				return decade + "年代", nil
+			}
+			year, err := t.an2cn.An2cn(subs[2]+subs[3], "direct")
+			if err != nil {
+				return inputs, err
+			}
+			return year + "财年", nil
 
 		case "fraction":
+			var convErr error
 			numRe := regexp.MustCompile(`\d+`)
 			result := numRe.ReplaceAllStringFunc(inputs, func(match string) string {
 				cnNum, err := t.an2cn.An2cn(match, "low")
 				if err != nil {
+					convErr = err
 					return match
 				}
 				return cnNum
 			})
 			parts := strings.Split(result, "/")
 			if len(parts) == 2 {
-				return fmt.Sprintf("%s分之%s", parts[1], parts[0])
+				return fmt.Sprintf("%s分之%s", parts[1], parts[0]), convErr
 			}
-			return inputs
+			return inputs, convErr
 
 		case "celsius":
 			if strings.HasSuffix(inputs, "℃") {
 				numPart := inputs[:len(inputs)-len("℃")]
 				result, err := t.an2cn.An2cn(numPart, "low")
 				if err != nil {
-					return inputs
+					return inputs, err
 				}
 				prefix := ""
 				trimmed := result
@@ -300,44 +667,128 @@ func (t *Transform) subUtil(inputs, method, subMode string) string {
 					prefix = "-"
 					trimmed = strings.TrimPrefix(result, "负")
 				}
-				return prefix + trimmed + "摄氏度"
+				return prefix + trimmed + "摄氏度", nil
 			}
-			return inputs
+			return inputs, nil
 
 		case "percent":
 			if strings.HasSuffix(inputs, "%") {
 				numPart := inputs[:len(inputs)-1]
 				result, err := t.an2cn.An2cn(numPart, "low")
 				if err != nil {
-					return inputs
+					return inputs, err
 				}
-				return "百分之" + result
+				return "百分之" + result, nil
 			}
-			return inputs
+			return inputs, nil
 
 		case "number":
 			result, err := t.an2cn.An2cn(inputs, "low")
 			if err != nil {
-				return inputs
+				return inputs, err
 			}
-			return result
+			return result, nil
 		}
 	}
 
-	return inputs
+	return inputs, nil
+}
+
+// isCnNumber 判断句子中的中文数字是否按数字转换：只由「两」组成时需后接量词或「点」，避免 两岸 => 2岸，
+// 两点 与 三点 一样作为钟点 => 2点；一半 等后接「半」的保持原样
+func isCnNumber(s string, start, end int) bool {
+	rest := s[end:]
+	if strings.Trim(s[start:end], "两兩") == "" && !measureWordPrefixRe.MatchString(rest) &&
+		!strings.HasPrefix(rest, "点") && !strings.HasPrefix(rest, "點") {
+		return false
+	}
+	return !strings.HasPrefix(rest, "半")
+}
+
+// isRelativeDate 判断句子中的相对日期是否改写：不带 上、下 等前缀的 周三、星期三 前面不能是数字或「每」，
+// 避免 一周三次、每周三 中的 周三 被当作日期
+func (t *Transform) isRelativeDate(s string, start, end int) bool {
+	if !strings.HasPrefix(s[start:], "周") && !strings.HasPrefix(s[start:], "星期") && !strings.HasPrefix(s[start:], "礼拜") {
+		return true
+	}
+	before, _ := utf8.DecodeLastRuneInString(s[:start])
+	return !unicode.IsDigit(before) && !strings.ContainsRune(t.allNum+t.allUnit+"每几幾", before)
+}
+
+// isCnUpperNumber 判断句子中的大写数字是否单独转换：紧邻小写数字时为 壹百零一 等混写，交由数字阶段处理
+func (t *Transform) isCnUpperNumber(s string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(s[:start])
+	after, _ := utf8.DecodeRuneInString(s[end:])
+	return !strings.ContainsRune(t.allNum, before) && !strings.ContainsRune(t.allNum, after)
 }
 
-func (t *Transform) preprocessAn2cnMathSymbols(s string) string {
+// replaceClockTimes 将 14:30、9:05:30 等时刻转为中文，跳过 1:2:3 等比例和更长的数字串
+func (t *Transform) replaceClockTimes(s string, rec *alignmentRecorder) string {
+	locs := timeTextColonRe.FindAllStringIndex(s, -1)
+	if locs == nil {
+		return s
+	}
+
+	var builder strings.Builder
+	var edits []textEdit
+	last := 0
+	for _, loc := range locs {
+		if !isClockBoundary(s, loc[0], loc[1]) {
+			continue
+		}
+		fields := strings.FieldsFunc(s[loc[0]:loc[1]], func(r rune) bool { return r == ':' || r == '：' })
+		var values [3]int
+		for i, field := range fields {
+			values[i], _ = strconv.Atoi(field)
+		}
+		result, err := t.an2cn.formatTimeOfDay(TimeOfDay{Hour: values[0], Minute: values[1], Second: values[2]}, "24h")
+		if err != nil {
+			continue
+		}
+		builder.WriteString(s[last:loc[0]])
+		builder.WriteString(result)
+		edits = append(edits, textEdit{start: loc[0], end: loc[1], text: result})
+		last = loc[1]
+	}
+	builder.WriteString(s[last:])
+	output := builder.String()
+	rec.record(output, edits)
+	return output
+}
+
+// isClockBoundary 判断 s[start:end] 两侧是否没有紧邻的数字、小数点或冒号
+func isClockBoundary(s string, start, end int) bool {
+	if start > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(s[:start])
+		if unicode.IsDigit(prev) || strings.ContainsRune(".:：", prev) {
+			return false
+		}
+	}
+	if end < len(s) {
+		next, _ := utf8.DecodeRuneInString(s[end:])
+		if unicode.IsDigit(next) || strings.ContainsRune(".:：", next) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *Transform) preprocessAn2cnMathSymbols(s string, rec *alignmentRecorder) string {
 	if s == "" {
 		return s
 	}
 
-	runes := []rune(s)
-	for i, r := range runes {
-		runes[i] = normalizeMinusRune(r)
+	var b editBuilder
+	for _, r := range s {
+		if normalized := normalizeMinusRune(r); normalized != r {
+			b.replace(string(r), string(normalized))
+		} else {
+			b.keep(r)
+		}
 	}
+	s = b.finish(rec)
 
-	return t.markBinaryMinus(string(runes))
+	return t.markBinaryMinus(s, rec)
 }
 
 func normalizeMinusRune(r rune) rune {
@@ -349,27 +800,26 @@ func normalizeMinusRune(r rune) rune {
 	}
 }
 
-func (t *Transform) markBinaryMinus(s string) string {
+func (t *Transform) markBinaryMinus(s string, rec *alignmentRecorder) string {
 	if s == "" {
 		return s
 	}
 
 	runes := []rune(s)
-	var builder strings.Builder
-	builder.Grow(len(runes) * 2)
+	var b editBuilder
 	for i, r := range runes {
 		if r == '-' {
 			if t.isBinaryMinus(runes, i) {
-				builder.WriteString(t.binaryMinusPlaceholder)
+				b.replace(string(r), t.binaryMinusPlaceholder)
 			} else {
-				builder.WriteRune(r)
+				b.keep(r)
 			}
 			continue
 		}
-		builder.WriteRune(r)
+		b.keep(r)
 	}
 
-	return builder.String()
+	return b.finish(rec)
 }
 
 func (t *Transform) isBinaryMinus(runes []rune, idx int) bool {
@@ -390,141 +840,180 @@ func (t *Transform) isBinaryMinus(runes []rune, idx int) bool {
 	return true
 }
 
-func (t *Transform) postprocessAn2cnMathSymbols(s string) string {
+func (t *Transform) postprocessAn2cnMathSymbols(s string, rec *alignmentRecorder) string {
 	if s == "" {
 		return s
 	}
 
-	s = strings.ReplaceAll(s, t.binaryMinusPlaceholder, "减")
-	s = t.replaceEmbeddedNegativeBetweenOperands(s)
-	s = t.replaceExponentNotation(s)
-	s = t.replaceAbsoluteValue(s)
-	if t.mathSymbolReplacer != nil {
-		s = t.mathSymbolReplacer.Replace(s)
-	}
-	s = t.replaceSlashSymbols(s)
-	s = t.replaceAsteriskSymbols(s)
-	s = t.convertRemainingMinus(s)
+	s = t.replaceBinaryMinusPlaceholder(s, rec)
+	s = t.replaceEmbeddedNegativeBetweenOperands(s, rec)
+	s = t.replaceExponentNotation(s, rec)
+	s = t.replaceAbsoluteValue(s, rec)
+	s = t.replaceMathSymbols(s, rec)
+	s = t.replaceSlashSymbols(s, rec)
+	s = t.replaceAsteriskSymbols(s, rec)
+	s = t.convertRemainingMinus(s, rec)
 
 	return s
 }
 
-func (t *Transform) replaceEmbeddedNegativeBetweenOperands(s string) string {
+// replaceBinaryMinusPlaceholder 将 markBinaryMinus 写入的占位符改为「减」
+func (t *Transform) replaceBinaryMinusPlaceholder(s string, rec *alignmentRecorder) string {
+	var builder strings.Builder
+	var edits []textEdit
+	last := 0
+	for {
+		i := strings.Index(s[last:], t.binaryMinusPlaceholder)
+		if i == -1 {
+			break
+		}
+		start := last + i
+		builder.WriteString(s[last:start])
+		builder.WriteString("减")
+		last = start + len(t.binaryMinusPlaceholder)
+		edits = append(edits, textEdit{start: start, end: last, text: "减"})
+	}
+	if edits == nil {
+		return s
+	}
+	builder.WriteString(s[last:])
+	result := builder.String()
+	rec.record(result, edits)
+	return result
+}
+
+func (t *Transform) replaceEmbeddedNegativeBetweenOperands(s string, rec *alignmentRecorder) string {
 	runes := []rune(s)
 	if len(runes) == 0 {
 		return s
 	}
 
-	var builder strings.Builder
-	builder.Grow(len(runes) * 2)
+	var b editBuilder
 	for i := 0; i < len(runes); i++ {
 		r := runes[i]
 		if r == '负' {
 			prevIdx, prev := previousNonSpaceRune(runes, i)
 			nextIdx, next := nextNonSpaceRune(runes, i)
 			if prevIdx != -1 && nextIdx != -1 && isMinusContextRune(prev) && isMinusContextRune(next) {
-				builder.WriteString("减")
+				b.replace(string(r), "减")
 				continue
 			}
 		}
-		builder.WriteRune(r)
+		b.keep(r)
 	}
 
-	return builder.String()
+	return b.finish(rec)
 }
 
-func (t *Transform) replaceSlashSymbols(s string) string {
+func (t *Transform) replaceSlashSymbols(s string, rec *alignmentRecorder) string {
 	runes := []rune(s)
 	if len(runes) == 0 {
 		return s
 	}
 
-	var builder strings.Builder
-	builder.Grow(len(runes) * 2)
+	var b editBuilder
 	for i := 0; i < len(runes); i++ {
 		r := runes[i]
 		if r == '/' {
 			if t.isDivisionSlash(runes, i) {
-				builder.WriteString("除以")
+				b.replace(string(r), "除以")
 			} else {
-				builder.WriteRune(r)
+				b.keep(r)
 			}
 			continue
 		}
-		builder.WriteRune(r)
+		b.keep(r)
 	}
 
-	return builder.String()
+	return b.finish(rec)
 }
 
-func (t *Transform) replaceAsteriskSymbols(s string) string {
+func (t *Transform) replaceAsteriskSymbols(s string, rec *alignmentRecorder) string {
 	runes := []rune(s)
 	if len(runes) == 0 {
 		return s
 	}
 
-	var builder strings.Builder
-	builder.Grow(len(runes) * 2)
+	var b editBuilder
 	for i := 0; i < len(runes); i++ {
 		r := runes[i]
 		if r == '*' {
 			if t.isMultiplicationAsterisk(runes, i) {
-				builder.WriteString("乘")
+				b.replace(string(r), "乘")
 			} else {
-				builder.WriteRune(r)
+				b.keep(r)
 			}
 			continue
 		}
-		builder.WriteRune(r)
+		b.keep(r)
 	}
 
-	return builder.String()
+	return b.finish(rec)
 }
 
-func (t *Transform) replaceExponentNotation(s string) string {
-	if !strings.ContainsRune(s, '^') {
-		return s
-	}
-
+func (t *Transform) replaceExponentNotation(s string, rec *alignmentRecorder) string {
 	for strings.ContainsRune(s, '^') {
-		replaced := exponentPattern.ReplaceAllStringFunc(s, func(match string) string {
-			subs := exponentPattern.FindStringSubmatch(match)
-			if len(subs) != 3 {
-				return match
-			}
-			base := strings.TrimSpace(subs[1])
-			exponent := strings.TrimSpace(subs[2])
+		var b editBuilder
+		for _, loc := range exponentPattern.FindAllStringSubmatchIndex(s, -1) {
+			base := strings.TrimSpace(s[loc[2]:loc[3]])
+			exponent := strings.TrimSpace(s[loc[4]:loc[5]])
 			if base == "" || exponent == "" {
-				return match
+				continue
 			}
-			return base + "的" + exponent + "次方"
-		})
-		if replaced == s {
+			b.keepString(s[b.pos:loc[0]])
+			b.replace(s[loc[0]:loc[1]], base+"的"+exponent+"次方")
+		}
+		if b.edits == nil {
 			break
 		}
-		s = replaced
+		b.keepString(s[b.pos:])
+		s = b.finish(rec)
 	}
 
 	return s
 }
 
-func (t *Transform) replaceAbsoluteValue(s string) string {
+// replaceMathSymbols 将数学符号替换为中文，同一位置按 mathSymbolPairs 中的顺序取第一个匹配，与 mathSymbolReplacer 一致
+func (t *Transform) replaceMathSymbols(s string, rec *alignmentRecorder) string {
+	replaced := t.mathSymbolReplacer.Replace(s)
+	if rec == nil || replaced == s {
+		return replaced
+	}
+
+	var b editBuilder
+	for b.pos < len(s) {
+		matched := false
+		for i := 0; i < len(t.mathSymbolPairs); i += 2 {
+			if old := t.mathSymbolPairs[i]; strings.HasPrefix(s[b.pos:], old) {
+				b.replace(old, t.mathSymbolPairs[i+1])
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			_, size := utf8.DecodeRuneInString(s[b.pos:])
+			b.keepString(s[b.pos : b.pos+size])
+		}
+	}
+	return b.finish(rec)
+}
+
+func (t *Transform) replaceAbsoluteValue(s string, rec *alignmentRecorder) string {
 	if !strings.ContainsRune(s, '|') {
 		return s
 	}
 
 	runes := []rune(s)
-	var builder strings.Builder
-	builder.Grow(len(runes) * 2)
+	var b editBuilder
 
 	for i := 0; i < len(runes); i++ {
 		r := runes[i]
 		if r != '|' {
-			builder.WriteRune(r)
+			b.keep(r)
 			continue
 		}
 		if (i+1 < len(runes) && runes[i+1] == '|') || (i-1 >= 0 && runes[i-1] == '|') {
-			builder.WriteRune(r)
+			b.keep(r)
 			continue
 		}
 		j := i + 1
@@ -532,30 +1021,29 @@ func (t *Transform) replaceAbsoluteValue(s string) string {
 			j++
 		}
 		if j >= len(runes) {
-			builder.WriteRune(r)
+			b.keep(r)
 			continue
 		}
 		inner := strings.TrimSpace(string(runes[i+1 : j]))
 		if inner == "" {
-			builder.WriteRune(r)
+			b.keep(r)
 			continue
 		}
-		builder.WriteString(inner)
-		builder.WriteString("的绝对值")
+		// 整对竖线作为一处改写，流式转换不会在其中切分而打乱之后的配对
+		b.replaceWhole(string(runes[i:j+1]), inner+"的绝对值")
 		i = j
 	}
 
-	return builder.String()
+	return b.finish(rec)
 }
 
-func (t *Transform) convertRemainingMinus(s string) string {
+func (t *Transform) convertRemainingMinus(s string, rec *alignmentRecorder) string {
 	runes := []rune(s)
 	if len(runes) == 0 {
 		return s
 	}
 
-	var builder strings.Builder
-	builder.Grow(len(runes) * 2)
+	var b editBuilder
 	for i := 0; i < len(runes); i++ {
 		r := runes[i]
 		if r == '-' {
@@ -563,25 +1051,25 @@ func (t *Transform) convertRemainingMinus(s string) string {
 			nextIdx, next := nextNonSpaceRune(runes, i)
 			if prevIdx != -1 && nextIdx != -1 {
 				if isHyphenInsideWord(runes, i, prevIdx, nextIdx) {
-					builder.WriteRune(r)
+					b.keep(r)
 					continue
 				}
 				if isBinaryMinusPrevOperand(prev) && isBinaryMinusNextOperand(next) {
-					builder.WriteString("减")
+					b.replace(string(r), "减")
 					continue
 				}
 			}
 			if nextIdx != -1 && isUnaryMinusOperand(next) {
 				if prevIdx == -1 || isUnaryMinusPrefixRune(prev) {
-					builder.WriteString("负")
+					b.replace(string(r), "负")
 					continue
 				}
 			}
 		}
-		builder.WriteRune(r)
+		b.keep(r)
 	}
 
-	return builder.String()
+	return b.finish(rec)
 }
 
 func (t *Transform) isDivisionSlash(runes []rune, idx int) bool {
@@ -2,6 +2,7 @@ package gocn2an
 
 import (
 	"testing"
+	"time"
 )
 
 func TestTransformStrictPairs(t *testing.T) {
@@ -127,3 +128,339 @@ func TestTransformAn2cnKeepsMarkdownAsterisks(t *testing.T) {
 		t.Errorf("Transform(%q, an2cn) = %q, want %q", input, got, expected)
 	}
 }
+
+func TestTransformTraditionalCn2an(t *testing.T) {
+	testData := map[string]string{
+		"共計肆佰萬圓整":  "共計4000000圓整",
+		"兩個人":      "2個人",
+		"人口三萬五千":   "人口35000",
+		"溫度下降三點五度": "溫度下降3.5度",
+		"大陸":       "大陸",
+		"參萬元":      "30000元",
+		"一百零一":     "101",
+		"一千零五十元":   "1050元",
+		"三万零二百":    "30200",
+	}
+
+	tr := NewTransform()
+	for input, expected := range testData {
+		result, err := tr.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %s, want %s", input, result, expected)
+		}
+	}
+}
+
+func TestTransformContractionsCn2an(t *testing.T) {
+	testData := map[string]string{
+		"卅五日":    "35日",
+		"廿一世纪":   "21世纪",
+		"佢廿幾歲":   "佢20幾歲",
+		"三點三個字見": "3點15分見",
+		"寫三個字":   "寫3個字",
+		"卌年":     "40年",
+	}
+
+	tr := NewTransform()
+	for input, expected := range testData {
+		result, err := tr.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %s, want %s", input, result, expected)
+		}
+	}
+}
+
+func TestTransformTimeOfDay(t *testing.T) {
+	cn2anData := map[string]string{
+		"我们两点半见":     "我们2:30见",
+		"下午三点一刻开会":   "15:15开会",
+		"会议十四点零五分开始": "会议14:05开始",
+		"差五分十点到":     "9:55到",
+		"兩點鐘":        "2:00",
+		"得了九点五分":     "得了9.5分",
+		"一点二三":       "1.23",
+		"三點三個字":      "3點15分",
+	}
+	an2cnData := map[string]string{
+		"14:30开会":    "十四点三十分开会",
+		"时间09:05:30": "时间九点零五分三十秒",
+		"比分3:2":      "比分三:二",
+		"1:2:3":      "一:二:三",
+		"晚上10点02分":   "晚上十点二分",
+	}
+
+	transform := NewTransform()
+	for input, expected := range cn2anData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+	for input, expected := range an2cnData {
+		result, err := transform.Transform(input, "an2cn")
+		if err != nil {
+			t.Errorf("Transform(%q, an2cn) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, an2cn) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestTransformDuration(t *testing.T) {
+	testData := map[string]string{
+		"等了一个半小时":   "等了1.5小时",
+		"半小时后出发":    "0.5小时后出发",
+		"三天两夜的旅行":   "3天2夜的旅行",
+		"跑了两分三十秒":   "跑了2分30秒",
+		"工作了一年零三个月": "工作了1年3个月",
+		"三个小时":      "3个小时",
+		"两个半月":      "2.5个月",
+		"三天半":       "3.5天",
+		"第二天":       "第2天",
+		"三分之一":      "1/3",
+	}
+
+	transform := NewTransform()
+	for input, expected := range testData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestTransformLunarDate(t *testing.T) {
+	testData := map[string]string{
+		"农历正月初一拜年":   "农历1月1日拜年",
+		"腊月廿三是小年":    "农历12月23日是小年",
+		"五月初五端午":     "农历5月5日端午",
+		"二〇二三年闰二月初十": "农历2023年闰2月10日",
+		"三月十五日":      "3月15日",
+		"五月廿三日":      "5月23日",
+		"三月卅一号":      "3月31号",
+		"农历五月廿三日":    "农历5月23日",
+	}
+
+	transform := NewTransform()
+	for input, expected := range testData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestTransformGanzhiAnnotation(t *testing.T) {
+	input := "甲辰年是龙年，庚子年是鼠年"
+
+	result, err := NewTransform().Transform(input, "cn2an")
+	if err != nil {
+		t.Fatalf("Transform(%q, cn2an) error: %v", input, err)
+	}
+	if result != input {
+		t.Errorf("Transform(%q, cn2an) = %q, want unchanged", input, result)
+	}
+
+	expected := "甲辰年（2024）是龙年，庚子年（2020）是鼠年"
+	result, err = NewTransform(WithGanzhiAnnotation(2024)).Transform(input, "cn2an")
+	if err != nil {
+		t.Fatalf("Transform(%q, cn2an) error: %v", input, err)
+	}
+	if result != expected {
+		t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+	}
+}
+
+func TestTransformRelativeDates(t *testing.T) {
+	reference := time.Date(2024, 6, 5, 10, 30, 0, 0, time.UTC)
+	testData := map[string]string{
+		"我们明天下午三点开会":  "我们2024-06-06 15:00开会",
+		"下周三交付，三天后复盘": "2024-06-12交付，2024-06-08复盘",
+		"今天天气不错":      "2024-06-05天气不错",
+		"上个月五号发工资":    "2024-05-05发工资",
+		"今年三月":        "2024-03",
+		"去年五月三号":      "2023-05-03",
+		"他今年三十岁":      "他今年30岁",
+		"一周三次":        "1周3次",
+		"每周三开会":       "每周3开会",
+		"周三开会":        "2024-06-05开会",
+	}
+
+	transform := NewTransform(WithRelativeDates(reference))
+	for input, expected := range testData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+
+	result, _ := NewTransform().Transform("明天下午三点开会", "cn2an")
+	if result != "明天15:00开会" {
+		t.Errorf("Transform without WithRelativeDates = %q, want %q", result, "明天15:00开会")
+	}
+}
+
+func TestTransformDateRange(t *testing.T) {
+	cn2anData := map[string]string{
+		"八十年代的老歌":     "80年代的老歌",
+		"二十一世纪":       "21世纪",
+		"上世纪九十年代末":    "上世纪90年代末",
+		"第三季度营收增长":    "Q3营收增长",
+		"二〇二四年第三季度":   "2024年Q3",
+		"二〇二四财年上半年":   "2024财年上半年",
+		"今年上半年卖了两个半月": "今年上半年卖了2.5个月",
+	}
+	an2cnData := map[string]string{
+		"80年代的老歌":  "八十年代的老歌",
+		"21世纪":     "二十一世纪",
+		"Q3营收增长":   "第三季度营收增长",
+		"2024年Q3":  "二零二四年第三季度",
+		"2024财年":   "二零二四财年",
+		"FY2024目标": "二零二四财年目标",
+	}
+
+	transform := NewTransform()
+	for input, expected := range cn2anData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+	for input, expected := range an2cnData {
+		result, err := transform.Transform(input, "an2cn")
+		if err != nil {
+			t.Errorf("Transform(%q, an2cn) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, an2cn) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestTransformLiangBan(t *testing.T) {
+	testData := map[string]string{
+		"两岸关系":       "两岸关系",
+		"半导体产业":      "半导体产业",
+		"一半的人":       "一半的人",
+		"两两相望":       "两两相望",
+		"半夜醒来":       "半夜醒来",
+		"今年上半年":      "今年上半年",
+		"两千五百人":      "2500人",
+		"来了两个人":      "来了2个人",
+		"一斤半":        "1.5斤",
+		"三块半":        "3.5块",
+		"半个苹果":       "0.5个苹果",
+		"等了一个半小时":    "等了1.5小时",
+		"两点半到两岸咖啡见面": "2:30到两岸咖啡见面",
+		"两点见":        "2点见",
+		"十二点见":       "12点见",
+		"两倍":         "2倍",
+		"两成":         "2成",
+		"两双鞋":        "2双鞋",
+		"两项任务":       "2项任务",
+		"半成品":        "半成品",
+		"半双工":        "半双工",
+	}
+
+	transform := NewTransform()
+	for input, expected := range testData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestTransformProtectedTerms(t *testing.T) {
+	testData := map[string]string{
+		"一心一意":      "一心一意",
+		"全国统一大市场":   "全国统一大市场",
+		"万一下雨":      "万一下雨",
+		"一些人":       "一些人",
+		"三星发布了三款手机": "三星发布了3款手机",
+		"九寨沟门票一百元":  "九寨沟门票100元",
+		"四川人口八千万":   "四川人口80000000",
+		"十一假期去了三亚":  "十一假期去了三亚",
+		"一万一千元":     "11000元",
+		"工期三星期":     "工期3星期",
+		"八达通充值五十元":  "八达通充值50元",
+		"双十一打折":     "双11打折",
+		"十一月十一日":    "11月11日",
+	}
+
+	transform := NewTransform(WithProtectedTerms("八达通"), WithForcedTerms(map[string]string{"双十一": "双11"}))
+	for input, expected := range testData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+}
+
+func TestTransformSegmenter(t *testing.T) {
+	testData := map[string]string{
+		"一流的三个产品":    "一流的3个产品",
+		"工期三星期":      "工期3星期",
+		"二〇二四年三月五日":  "2024年3月5日",
+		"两点半见面":      "2:30见面",
+		"一流等了两个小时":   "一流等了2个小时",
+		"他是一流的选手":    "他是一流的选手",
+		"十分重要，等了十分钟": "十分重要，等了10分钟",
+	}
+
+	transform := NewTransform(WithSegmenter(NewMaxMatchSegmenter(nil)))
+	for input, expected := range testData {
+		result, err := transform.Transform(input, "cn2an")
+		if err != nil {
+			t.Errorf("Transform(%q, cn2an) error: %v", input, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Transform(%q, cn2an) = %q, want %q", input, result, expected)
+		}
+	}
+
+	result, _ := NewTransform().Transform("一流的三个产品", "cn2an")
+	if result != "1流的3个产品" {
+		t.Errorf("Transform without WithSegmenter = %q, want %q", result, "1流的3个产品")
+	}
+}